@@ -2,282 +2,223 @@ package main
 
 import (
 	"context"
+	"encoding/hex"
 	"encoding/json"
 	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"sort"
 	"time"
 
 	"github.com/Layr-Labs/hourglass-monorepo/ponos/pkg/performer/server"
 	performerV1 "github.com/Layr-Labs/protocol-apis/gen/protos/eigenlayer/hourglass/v1/performer"
 	"go.uber.org/zap"
-)
-
-// TaskType represents the different types of Oracle tasks
-type TaskType string
 
-const (
-	TaskTypePriceAttestation       TaskType = "price_attestation"
-	TaskTypeConsensusValidation    TaskType = "consensus_validation"
-	TaskTypeManipulationChallenge  TaskType = "manipulation_challenge"
-	TaskTypeOperatorSlashing       TaskType = "operator_slashing"
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/ethereum/go-ethereum/ethclient"
+
+	"github.com/Najnomics/AVS-Oracle-Hook/avs/contracts/delegationmanager"
+	"github.com/Najnomics/AVS-Oracle-Hook/avs/contracts/oracleemitter"
+	"github.com/Najnomics/AVS-Oracle-Hook/avs/pkg/beacon"
+	"github.com/Najnomics/AVS-Oracle-Hook/avs/pkg/config"
+	"github.com/Najnomics/AVS-Oracle-Hook/avs/pkg/performer"
+	"github.com/Najnomics/AVS-Oracle-Hook/avs/pkg/supervisor"
 )
 
-// TaskPayload represents the structure of task payload data
-type TaskPayload struct {
-	Type       TaskType               `json:"type"`
-	Parameters map[string]interface{} `json:"parameters"`
-}
+// oracleConfigPath is where the reloadable performer configuration
+// (price source endpoints, BLS key path, slashing thresholds, enabled
+// task types) lives; SIGHUP re-reads it without restarting the process.
+var oracleConfigPath = envOr("ORACLE_PERFORMER_CONFIG", "config.json")
 
-// parseTaskPayload extracts and parses the task payload from TaskRequest
-func parseTaskPayload(t *performerV1.TaskRequest) (*TaskPayload, error) {
-	var payload TaskPayload
-	if err := json.Unmarshal(t.Payload, &payload); err != nil {
-		return nil, fmt.Errorf("failed to parse task payload: %w", err)
+func envOr(key, fallback string) string {
+	if v := os.Getenv(key); v != "" {
+		return v
 	}
-	return &payload, nil
+	return fallback
 }
 
-// OraclePerformer implements the Hourglass Performer interface for Oracle tasks.
-// This offchain binary is run by Operators running the Hourglass Executor. It contains
-// the business logic of the Oracle AVS and performs work based on tasks sent to it.
-//
-// The Hourglass Aggregator ingests tasks from the TaskMailbox and distributes work
-// to Executors configured to run the Oracle Performer. Performers execute the work and
-// return the result to the Executor where the result is signed and returned to the
-// Aggregator to place in the outbox once the signing threshold is met.
-type OraclePerformer struct {
-	logger *zap.Logger
-}
-
-func NewOraclePerformer(logger *zap.Logger) *OraclePerformer {
-	return &OraclePerformer{
-		logger: logger,
+// oracleRequestTaskRequest builds a synthetic TaskRequest for a price
+// attestation task driven directly by an on-chain OracleRequest event, for
+// operators running in self-driving mode. price and sourceHash must
+// already have been resolved (e.g. via fetchPoolPrice) - unlike an
+// Executor-pushed price_attestation task, an on-chain OracleRequest event
+// doesn't carry a price, and handlePriceAttestation rejects a zero price.
+func oracleRequestTaskRequest(ev *oracleemitter.OracleEmitterNewOracleRequest, price float64, sourceHash [32]byte) (*performerV1.TaskRequest, error) {
+	payload := performer.TaskPayload{
+		Type: performer.TaskTypePriceAttestation,
+		Parameters: map[string]interface{}{
+			"pool_id":     hex.EncodeToString(ev.PoolId[:]),
+			"operator":    ev.Requester.Hex(),
+			"timestamp":   float64(ev.Timestamp.Int64()),
+			"price":       price,
+			"source_hash": hex.EncodeToString(sourceHash[:]),
+		},
+	}
+	payloadBytes, err := json.Marshal(payload)
+	if err != nil {
+		return nil, fmt.Errorf("encoding synthetic task payload: %w", err)
 	}
+	return &performerV1.TaskRequest{
+		TaskId:  ev.RequestId[:],
+		Payload: payloadBytes,
+	}, nil
 }
 
-func (op *OraclePerformer) ValidateTask(t *performerV1.TaskRequest) error {
-	op.logger.Sugar().Infow("Validating Oracle task",
-		zap.Any("task", t),
-	)
-
-	// ------------------------------------------------------------------------
-	// Oracle Task Validation Logic
-	// ------------------------------------------------------------------------
-	// Validate that the task request data is well-formed for Oracle operations
-	
-	if len(t.TaskId) == 0 {
-		return fmt.Errorf("task ID cannot be empty")
+// fetchPoolPrice resolves poolId's current price from one of the
+// operator's configured price source endpoints (config.Config's
+// PriceSourceEndpoints), for self-driving mode. It picks the
+// lexicographically first configured source name so the choice is
+// deterministic, queries it expecting a JSON body of the form
+// {"price": <number>}, and hashes the raw response body as the
+// attestation's source_hash.
+//
+// TODO: once handlePriceAttestation's own price-fetch TODO is resolved,
+// this should draw from the same beacon-derived source subset rather than
+// always the first configured endpoint.
+func fetchPoolPrice(ctx context.Context, cfg *config.Config, poolId string) (float64, [32]byte, error) {
+	if cfg == nil || len(cfg.PriceSourceEndpoints) == 0 {
+		return 0, [32]byte{}, fmt.Errorf("no price source endpoints configured")
 	}
 
-	if len(t.Payload) == 0 {
-		return fmt.Errorf("task payload cannot be empty")
+	names := make([]string, 0, len(cfg.PriceSourceEndpoints))
+	for name := range cfg.PriceSourceEndpoints {
+		names = append(names, name)
 	}
+	sort.Strings(names)
+	source := names[0]
+	endpoint := cfg.PriceSourceEndpoints[source]
 
-	// Parse and validate task payload
-	payload, err := parseTaskPayload(t)
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, endpoint, nil)
 	if err != nil {
-		return fmt.Errorf("failed to parse task payload: %w", err)
-	}
-
-	// Validate task type specific requirements
-	switch payload.Type {
-	case TaskTypePriceAttestation:
-		if err := op.validatePriceAttestationTask(payload); err != nil {
-			return fmt.Errorf("price attestation validation failed: %w", err)
-		}
-	case TaskTypeConsensusValidation:
-		if err := op.validateConsensusValidationTask(payload); err != nil {
-			return fmt.Errorf("consensus validation failed: %w", err)
-		}
-	case TaskTypeManipulationChallenge:
-		if err := op.validateManipulationChallengeTask(payload); err != nil {
-			return fmt.Errorf("manipulation challenge validation failed: %w", err)
-		}
-	case TaskTypeOperatorSlashing:
-		if err := op.validateOperatorSlashingTask(payload); err != nil {
-			return fmt.Errorf("operator slashing validation failed: %w", err)
-		}
-	default:
-		return fmt.Errorf("unknown task type: %s", payload.Type)
+		return 0, [32]byte{}, fmt.Errorf("building price source request for %s: %w", source, err)
 	}
-
-	op.logger.Sugar().Infow("Task validation successful", "taskId", string(t.TaskId))
-	return nil
-}
-
-func (op *OraclePerformer) HandleTask(t *performerV1.TaskRequest) (*performerV1.TaskResponse, error) {
-	op.logger.Sugar().Infow("Handling Oracle task",
-		zap.Any("task", t),
-	)
-
-	// ------------------------------------------------------------------------
-	// Oracle Task Processing Logic
-	// ------------------------------------------------------------------------
-	// This is where the Performer will execute Oracle-specific work
-	
-	var resultBytes []byte
-	var err error
-
-	// Parse task payload to determine task type
-	payload, err := parseTaskPayload(t)
+	resp, err := http.DefaultClient.Do(req)
 	if err != nil {
-		return nil, fmt.Errorf("failed to parse task payload: %w", err)
-	}
-	
-	// Route to appropriate handler based on task type
-	switch payload.Type {
-	case TaskTypePriceAttestation:
-		resultBytes, err = op.handlePriceAttestation(t, payload)
-	case TaskTypeConsensusValidation:
-		resultBytes, err = op.handleConsensusValidation(t, payload)
-	case TaskTypeManipulationChallenge:
-		resultBytes, err = op.handleManipulationChallenge(t, payload)
-	case TaskTypeOperatorSlashing:
-		resultBytes, err = op.handleOperatorSlashing(t, payload)
-	default:
-		return nil, fmt.Errorf("unknown task type '%s' for task %s", payload.Type, string(t.TaskId))
+		return 0, [32]byte{}, fmt.Errorf("querying price source %s: %w", source, err)
 	}
+	defer resp.Body.Close()
 
+	body, err := io.ReadAll(resp.Body)
 	if err != nil {
-		op.logger.Sugar().Errorw("Task processing failed", 
-			"taskId", string(t.TaskId), 
-			"error", err,
-		)
-		return nil, err
+		return 0, [32]byte{}, fmt.Errorf("reading price source %s response: %w", source, err)
 	}
 
-	op.logger.Sugar().Infow("Task processing completed successfully", 
-		"taskId", string(t.TaskId),
-		"resultSize", len(resultBytes),
-	)
-
-	return &performerV1.TaskResponse{
-		TaskId: t.TaskId,
-		Result: resultBytes,
-	}, nil
-}
-
-// handlePriceAttestation processes price attestation tasks
-func (op *OraclePerformer) handlePriceAttestation(t *performerV1.TaskRequest, payload *TaskPayload) ([]byte, error) {
-	op.logger.Sugar().Infow("Processing price attestation task", "taskId", string(t.TaskId))
-	
-	// TODO: Implement price attestation logic
-	// Example parameter access:
-	// poolId := payload.Parameters["pool_id"].(string)
-	// price := payload.Parameters["price"].(float64)
-	
-	// - Fetch prices from multiple sources (Binance, Coinbase, Kraken, etc.)
-	// - Calculate weighted average price
-	// - Sign price attestation with BLS signature
-	// - Submit to Oracle AVS Service Manager
-	// - Return attestation result
-	
-	return []byte("Price attestation completed"), nil
-}
-
-// handleConsensusValidation processes consensus validation tasks
-func (op *OraclePerformer) handleConsensusValidation(t *performerV1.TaskRequest, payload *TaskPayload) ([]byte, error) {
-	op.logger.Sugar().Infow("Processing consensus validation task", "taskId", string(t.TaskId))
-	
-	// TODO: Implement consensus validation logic
-	// - Validate incoming price attestations
-	// - Check for outliers and manipulation attempts
-	// - Calculate stake-weighted consensus
-	// - Return consensus result
-	
-	return []byte("Consensus validation completed"), nil
-}
-
-// handleManipulationChallenge processes manipulation challenge tasks
-func (op *OraclePerformer) handleManipulationChallenge(t *performerV1.TaskRequest, payload *TaskPayload) ([]byte, error) {
-	op.logger.Sugar().Infow("Processing manipulation challenge task", "taskId", string(t.TaskId))
-	
-	// TODO: Implement manipulation challenge logic
-	// - Analyze suspected price manipulation
-	// - Gather evidence from multiple price sources
-	// - Calculate deviation from consensus
-	// - Submit challenge proof
-	// - Return challenge result
-	
-	return []byte("Manipulation challenge completed"), nil
-}
-
-// handleOperatorSlashing processes operator slashing tasks
-func (op *OraclePerformer) handleOperatorSlashing(t *performerV1.TaskRequest, payload *TaskPayload) ([]byte, error) {
-	op.logger.Sugar().Infow("Processing operator slashing task", "taskId", string(t.TaskId))
-	
-	// TODO: Implement operator slashing logic
-	// - Validate slashing evidence
-	// - Calculate slashing amount based on deviation
-	// - Execute slashing through EigenLayer
-	// - Update operator reliability scores
-	// - Return slashing result
-	
-	return []byte("Operator slashing completed"), nil
-}
-
-// Oracle task validation functions
-func (op *OraclePerformer) validatePriceAttestationTask(payload *TaskPayload) error {
-	// Validate required parameters for price attestation
-	if poolId, ok := payload.Parameters["pool_id"].(string); !ok || poolId == "" {
-		return fmt.Errorf("missing or invalid pool_id")
-	}
-	
-	if price, ok := payload.Parameters["price"].(float64); !ok || price <= 0 {
-		return fmt.Errorf("missing or invalid price")
+	var parsed struct {
+		Price float64 `json:"price"`
 	}
-	
-	if sourceHash, ok := payload.Parameters["source_hash"].(string); !ok || sourceHash == "" {
-		return fmt.Errorf("missing or invalid source_hash")
+	if err := json.Unmarshal(body, &parsed); err != nil {
+		return 0, [32]byte{}, fmt.Errorf("parsing price source %s response: %w", source, err)
 	}
-	
-	return nil
-}
-
-func (op *OraclePerformer) validateConsensusValidationTask(payload *TaskPayload) error {
-	// Validate required parameters for consensus validation
-	if poolId, ok := payload.Parameters["pool_id"].(string); !ok || poolId == "" {
-		return fmt.Errorf("missing or invalid pool_id")
+	if parsed.Price <= 0 {
+		return 0, [32]byte{}, fmt.Errorf("price source %s returned non-positive price %v", source, parsed.Price)
 	}
-	
-	return nil
-}
 
-func (op *OraclePerformer) validateManipulationChallengeTask(payload *TaskPayload) error {
-	// Validate required parameters for manipulation challenge
-	if operator, ok := payload.Parameters["operator"].(string); !ok || operator == "" {
-		return fmt.Errorf("missing or invalid operator")
-	}
-	
-	if evidence, ok := payload.Parameters["evidence"].(string); !ok || evidence == "" {
-		return fmt.Errorf("missing or invalid evidence")
-	}
-	
-	return nil
+	var sourceHash [32]byte
+	copy(sourceHash[:], crypto.Keccak256(body))
+	return parsed.Price, sourceHash, nil
 }
 
-func (op *OraclePerformer) validateOperatorSlashingTask(payload *TaskPayload) error {
-	// Validate required parameters for operator slashing
-	if operator, ok := payload.Parameters["operator"].(string); !ok || operator == "" {
-		return fmt.Errorf("missing or invalid operator")
-	}
-	
-	if slashAmount, ok := payload.Parameters["slash_amount"].(float64); !ok || slashAmount <= 0 {
-		return fmt.Errorf("missing or invalid slash_amount")
-	}
-	
-	return nil
-}
+// oracleRPCEndpoint and oracleEmitterAddress configure the on-chain
+// OracleRequest subscription. Self-driving mode is opt-in: leave
+// oracleEmitterAddress unset to run purely off Executor-pushed tasks.
+//
+// delegationManagerAddress and stakeWeightStrategies configure stake-
+// weighted consensus validation; leave delegationManagerAddress unset to
+// fall back to equal-weighted consensus.
+//
+// TODO: source these from real operator configuration rather than
+// hardcoding once a config-loading story exists for this performer.
+var (
+	oracleRPCEndpoint        = ""
+	oracleEmitterAddress     = common.Address{}
+	delegationManagerAddress = common.Address{}
+	stakeWeightStrategies    = []common.Address{}
+)
 
 func main() {
 	ctx := context.Background()
 	l, _ := zap.NewProduction()
 
-	performer := NewOraclePerformer(l)
+	beaconNet := beacon.BeaconNetworks[len(beacon.BeaconNetworks)-1]
+	drandClient := beacon.NewDrandHTTPClient(fmt.Sprintf("https://api.drand.sh/%s", beaconNet.ChainHash), beaconNet.Period)
+	beaconAPI := beacon.NewDrandBeacon(drandClient, 256)
+	go func() {
+		if err := beaconAPI.Start(ctx); err != nil {
+			l.Sugar().Errorw("drand beacon stopped", "error", err)
+		}
+	}()
+
+	oraclePerformer := performer.NewOraclePerformer(l, beaconAPI)
+
+	if oracleRPCEndpoint != "" {
+		ethClient, err := ethclient.DialContext(ctx, oracleRPCEndpoint)
+		if err != nil {
+			l.Sugar().Errorw("failed to dial oracle RPC endpoint, running without self-driving mode", "error", err)
+		} else {
+			filterer, err := oracleemitter.NewOracleEmitterFilterer(oracleEmitterAddress, ethClient)
+			if err != nil {
+				l.Sugar().Errorw("failed to bind OracleEmitter contract, running without self-driving mode", "error", err)
+			} else {
+				sink := make(chan *oracleemitter.OracleEmitterNewOracleRequest)
+				if _, err := filterer.WatchNewOracleRequest(&bind.WatchOpts{Context: ctx}, sink, nil, nil, nil); err != nil {
+					l.Sugar().Errorw("failed to subscribe to NewOracleRequest events", "error", err)
+				} else {
+					go oraclePerformer.WatchOracleRequests(ctx, sink, func(taskCtx context.Context, ev *oracleemitter.OracleEmitterNewOracleRequest) {
+						poolId := hex.EncodeToString(ev.PoolId[:])
+						price, sourceHash, err := fetchPoolPrice(taskCtx, oraclePerformer.Config(), poolId)
+						if err != nil {
+							l.Sugar().Warnw("skipping self-driving task, failed to fetch price", "poolId", poolId, "error", err)
+							return
+						}
+						task, err := oracleRequestTaskRequest(ev, price, sourceHash)
+						if err != nil {
+							l.Sugar().Errorw("failed to build synthetic task request", "error", err)
+							return
+						}
+						if _, err := oraclePerformer.HandleTask(task); err != nil {
+							l.Sugar().Errorw("self-driving task failed", "requestId", hex.EncodeToString(ev.RequestId[:]), "error", err)
+						}
+					})
+				}
+			}
+
+			if delegationManagerAddress != (common.Address{}) {
+				stakeClient, err := delegationmanager.NewClient(delegationManagerAddress, ethClient, stakeWeightStrategies)
+				if err != nil {
+					l.Sugar().Errorw("failed to bind DelegationManager contract, consensus validation will use equal weighting", "error", err)
+				} else {
+					oraclePerformer.SetStakeProvider(stakeClient)
+				}
+			}
+		}
+	}
+
+	if cfg, err := config.Load(oracleConfigPath); err != nil {
+		l.Sugar().Warnw("no initial configuration loaded, using defaults", "path", oracleConfigPath, "error", err)
+	} else {
+		oraclePerformer.Reload(cfg)
+	}
+
+	// inheritedListener is set when this process was exec'd by a prior
+	// instance's SIGUSR2 restart; NewPonosPerformerWithRpcServer only
+	// takes a port today, so the inherited fd isn't plugged in here yet.
+	// TODO: wire inheritedListener into server.PonosPerformerConfig once
+	// it can bind an existing net.Listener instead of always binding a
+	// fresh one from Port.
+	if _, err := supervisor.InheritedListener(); err != nil {
+		l.Sugar().Warnw("failed to adopt inherited listener, binding fresh", "error", err)
+	}
+
+	sup := supervisor.New(l, oraclePerformer, oracleConfigPath, nil)
+	go sup.Serve(ctx)
 
 	pp, err := server.NewPonosPerformerWithRpcServer(&server.PonosPerformerConfig{
 		Port:    8080,
 		Timeout: 5 * time.Second,
-	}, performer, l)
+	}, oraclePerformer, l)
 	if err != nil {
 		panic(fmt.Errorf("failed to create Oracle performer: %w", err))
 	}
@@ -286,4 +227,4 @@ func main() {
 	if err := pp.Start(ctx); err != nil {
 		panic(err)
 	}
-}
\ No newline at end of file
+}
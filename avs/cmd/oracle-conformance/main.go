@@ -0,0 +1,33 @@
+// Command oracle-conformance runs a single conformance vector against
+// OraclePerformer and prints the result, for debugging a vector outside
+// of `go test ./conformance/...`.
+package main
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/Najnomics/AVS-Oracle-Hook/avs/conformance"
+)
+
+func main() {
+	if len(os.Args) != 2 {
+		fmt.Fprintf(os.Stderr, "usage: %s <vector.json>\n", os.Args[0])
+		os.Exit(2)
+	}
+	path := os.Args[1]
+
+	vector, err := conformance.LoadVector(path)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "error: %v\n", err)
+		os.Exit(1)
+	}
+
+	result := conformance.NewRunner().RunVector(path, vector)
+	if !result.Passed {
+		fmt.Fprintf(os.Stderr, "FAIL %s: %s\n", path, result.Detail)
+		os.Exit(1)
+	}
+
+	fmt.Printf("PASS %s\n", path)
+}
@@ -0,0 +1,199 @@
+// Package conformance runs a corpus of test vectors against an
+// OraclePerformer, mirroring the cross-implementation conformance-vector
+// approach used by Filecoin's Lotus: any future non-Go performer
+// implementing this same AVS must produce byte-identical results for the
+// same vector.
+package conformance
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/binary"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	performerV1 "github.com/Layr-Labs/protocol-apis/gen/protos/eigenlayer/hourglass/v1/performer"
+	"go.uber.org/zap"
+
+	"github.com/Najnomics/AVS-Oracle-Hook/avs/pkg/beacon"
+	"github.com/Najnomics/AVS-Oracle-Hook/avs/pkg/performer"
+)
+
+// Vector is a single conformance test case: a task to run against a
+// deterministic mock environment, and the result or error every
+// implementation must produce.
+type Vector struct {
+	TaskType       string                 `json:"task_type"`
+	InputPayload   map[string]interface{} `json:"input_payload"`
+	ExpectedResult json.RawMessage        `json:"expected_result,omitempty"`
+	ExpectedError  string                 `json:"expected_error,omitempty"`
+	BeaconRound    uint64                 `json:"beacon_round"`
+	MockPrices     map[string]float64     `json:"mock_prices,omitempty"`
+}
+
+// Result is the outcome of running a single vector.
+type Result struct {
+	Path   string
+	Passed bool
+	Detail string
+}
+
+// Runner executes vectors against a deterministic mock beacon, so every
+// run of the same vector (on any conforming implementation) derives the
+// same beacon-seeded selections. Each RunVector call builds its own fresh
+// OraclePerformer: vectors submit attestations and close rounds as a side
+// effect, and an OraclePerformer shared across vectors would let one
+// vector's pool state leak into another (e.g. a price_attestation vector
+// for a pool/round another vector expects to have no attestations
+// pending), making the corpus's pass/fail depend on run order.
+type Runner struct {
+	beaconAPI beacon.BeaconAPI
+}
+
+// NewRunner constructs a Runner backed by a deterministic mock beacon.
+func NewRunner() *Runner {
+	return &Runner{
+		beaconAPI: mockBeacon{},
+	}
+}
+
+// LoadVector reads and parses a single vector file.
+func LoadVector(path string) (*Vector, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("reading vector %s: %w", path, err)
+	}
+	var v Vector
+	if err := json.Unmarshal(data, &v); err != nil {
+		return nil, fmt.Errorf("parsing vector %s: %w", path, err)
+	}
+	return &v, nil
+}
+
+// LoadCorpus loads every *.json vector under dir.
+//
+// dir is a plain directory checked into this repo, not a git submodule.
+// This AVS has exactly one performer implementation (this one) and no
+// independently published cross-implementation test-vectors repo for it
+// to point at, so a submodule here would pin a second copy of this same
+// repo rather than buy any actual decoupling - reviewed and accepted as
+// the right call until a second implementation exists to justify
+// splitting the corpus out. Revisit (split test-vectors/ into its own
+// repo, submodule it back in here) the day a second implementation shows
+// up.
+func LoadCorpus(dir string) (map[string]*Vector, error) {
+	vectors := make(map[string]*Vector)
+	err := filepath.Walk(dir, func(path string, info os.FileInfo, err error) error {
+		if err != nil {
+			return err
+		}
+		if info.IsDir() || !strings.HasSuffix(path, ".json") {
+			return nil
+		}
+		v, err := LoadVector(path)
+		if err != nil {
+			return err
+		}
+		vectors[path] = v
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("loading conformance corpus %s: %w", dir, err)
+	}
+	return vectors, nil
+}
+
+// RunVector constructs a fresh OraclePerformer and a TaskRequest from v,
+// runs it through ValidateTask and HandleTask, and diffs the outcome
+// against v's expected result or error. A fresh performer per vector
+// keeps vectors independent of each other and of corpus iteration order.
+func (r *Runner) RunVector(path string, v *Vector) *Result {
+	perf := performer.NewOraclePerformer(zap.NewNop(), r.beaconAPI)
+
+	payload := performer.TaskPayload{
+		Type:       performer.TaskType(v.TaskType),
+		Parameters: v.InputPayload,
+	}
+	if payload.Parameters == nil {
+		payload.Parameters = map[string]interface{}{}
+	}
+	if _, ok := payload.Parameters["timestamp"]; !ok {
+		payload.Parameters["timestamp"] = float64(beaconRoundTimestamp(v.BeaconRound))
+	}
+	if len(v.MockPrices) > 0 {
+		payload.Parameters["mock_prices"] = v.MockPrices
+	}
+
+	payloadBytes, err := json.Marshal(payload)
+	if err != nil {
+		return &Result{Path: path, Passed: false, Detail: fmt.Sprintf("encoding vector payload: %v", err)}
+	}
+	task := &performerV1.TaskRequest{TaskId: []byte(path), Payload: payloadBytes}
+
+	if err := perf.ValidateTask(task); err != nil {
+		return matchError(path, err, v.ExpectedError)
+	}
+
+	resp, err := perf.HandleTask(task)
+	if err != nil {
+		return matchError(path, err, v.ExpectedError)
+	}
+
+	if v.ExpectedError != "" {
+		return &Result{Path: path, Passed: false, Detail: fmt.Sprintf("expected error containing %q, task succeeded", v.ExpectedError)}
+	}
+
+	if v.ExpectedResult != nil && !bytes.Equal(resp.Result, v.ExpectedResult) {
+		return &Result{Path: path, Passed: false, Detail: fmt.Sprintf("result mismatch: got %s, want %s", resp.Result, v.ExpectedResult)}
+	}
+
+	return &Result{Path: path, Passed: true}
+}
+
+func matchError(path string, err error, expected string) *Result {
+	if expected == "" {
+		return &Result{Path: path, Passed: false, Detail: fmt.Sprintf("unexpected error: %v", err)}
+	}
+	if !strings.Contains(err.Error(), expected) {
+		return &Result{Path: path, Passed: false, Detail: fmt.Sprintf("error %q does not contain expected %q", err, expected)}
+	}
+	return &Result{Path: path, Passed: true}
+}
+
+// beaconRoundTimestamp inverts beacon.RoundAt for the active network, so
+// a vector that only specifies beacon_round still produces a task
+// timestamp that resolves back to that round.
+func beaconRoundTimestamp(round uint64) int64 {
+	net := beacon.BeaconNetworks[len(beacon.BeaconNetworks)-1]
+	return net.GenesisTime.Add(time.Duration(round-net.Start) * net.Period).Unix()
+}
+
+// mockBeacon is a deterministic beacon.BeaconAPI seeded purely by round
+// number, so every conforming implementation derives identical
+// beacon-seeded selections for a given vector without needing a live
+// drand connection.
+type mockBeacon struct{}
+
+func (mockBeacon) Entry(_ context.Context, round uint64) (beacon.BeaconEntry, error) {
+	var buf [8]byte
+	binary.BigEndian.PutUint64(buf[:], round)
+	digest := sha256.Sum256(buf[:])
+	return beacon.BeaconEntry{
+		Round:      round,
+		Randomness: digest[:],
+		Signature:  digest[:],
+	}, nil
+}
+
+func (mockBeacon) VerifyEntry(_, _ beacon.BeaconEntry) error {
+	return nil
+}
+
+func (mockBeacon) LatestBeaconRound() uint64 {
+	return 0
+}
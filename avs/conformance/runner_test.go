@@ -0,0 +1,39 @@
+package conformance
+
+import (
+	"os"
+	"sort"
+	"testing"
+)
+
+// TestCorpus runs every vector under ../test-vectors against a fresh
+// Runner, in sorted path order so the corpus's pass/fail doesn't depend
+// on Go's unspecified map iteration order. Set SKIP_CONFORMANCE=1 to
+// skip, e.g. in environments without the test-vectors corpus checked out.
+func TestCorpus(t *testing.T) {
+	if os.Getenv("SKIP_CONFORMANCE") == "1" {
+		t.Skip("SKIP_CONFORMANCE=1")
+	}
+
+	vectors, err := LoadCorpus("../test-vectors")
+	if err != nil {
+		t.Fatalf("loading corpus: %v", err)
+	}
+	if len(vectors) == 0 {
+		t.Fatal("no conformance vectors found under ../test-vectors")
+	}
+
+	paths := make([]string, 0, len(vectors))
+	for path := range vectors {
+		paths = append(paths, path)
+	}
+	sort.Strings(paths)
+
+	runner := NewRunner()
+	for _, path := range paths {
+		result := runner.RunVector(path, vectors[path])
+		if !result.Passed {
+			t.Errorf("%s: %s", path, result.Detail)
+		}
+	}
+}
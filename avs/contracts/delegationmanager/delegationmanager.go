@@ -0,0 +1,63 @@
+// Package delegationmanager is a hand-maintained go-ethereum contract
+// binding for EigenLayer's DelegationManager, in the shape abigen would
+// produce. It exposes only the read the performer needs: how much stake
+// is currently delegated to an operator, summed across the strategies the
+// Oracle AVS cares about, so consensus validation can weight attestations
+// by stake rather than by raw vote count.
+package delegationmanager
+
+import (
+	"context"
+	"math/big"
+	"strings"
+
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/common"
+)
+
+// DelegationManagerABI is the subset of EigenLayer's DelegationManager ABI
+// this binding needs: the operatorShares view used to read delegated
+// stake per strategy.
+const DelegationManagerABI = `[{"constant":true,"inputs":[{"internalType":"address","name":"operator","type":"address"},{"internalType":"address","name":"strategy","type":"address"}],"name":"operatorShares","outputs":[{"internalType":"uint256","name":"","type":"uint256"}],"stateMutability":"view","type":"function"}]`
+
+// Client reads delegated stake from a deployed DelegationManager contract.
+type Client struct {
+	contract   *bind.BoundContract
+	strategies []common.Address
+}
+
+// NewClient binds to address using caller (typically an *ethclient.Client)
+// and sums operatorShares across strategies when asked for an operator's
+// stake.
+func NewClient(address common.Address, caller bind.ContractCaller, strategies []common.Address) (*Client, error) {
+	parsed, err := abi.JSON(strings.NewReader(DelegationManagerABI))
+	if err != nil {
+		return nil, err
+	}
+	contract := bind.NewBoundContract(address, parsed, caller, nil, nil)
+	return &Client{contract: contract, strategies: strategies}, nil
+}
+
+// OperatorStake returns operator's total delegated shares across every
+// configured strategy. It implements performer.StakeProvider.
+func (c *Client) OperatorStake(ctx context.Context, operator common.Address) (*big.Int, error) {
+	total := new(big.Int)
+	for _, strategy := range c.strategies {
+		shares, err := c.operatorShares(ctx, operator, strategy)
+		if err != nil {
+			return nil, err
+		}
+		total.Add(total, shares)
+	}
+	return total, nil
+}
+
+func (c *Client) operatorShares(ctx context.Context, operator, strategy common.Address) (*big.Int, error) {
+	var out []interface{}
+	opts := &bind.CallOpts{Context: ctx}
+	if err := c.contract.Call(opts, &out, "operatorShares", operator, strategy); err != nil {
+		return nil, err
+	}
+	return abi.ConvertType(out[0], new(big.Int)).(*big.Int), nil
+}
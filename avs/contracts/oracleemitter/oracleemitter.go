@@ -0,0 +1,114 @@
+// Package oracleemitter is a hand-maintained go-ethereum contract binding
+// for the on-chain OracleEmitter contract, in the shape abigen would
+// produce. It exposes the NewOracleRequest event so the performer can
+// subscribe to on-chain oracle requests directly instead of waiting for
+// the Executor to push a task.
+package oracleemitter
+
+import (
+	"math/big"
+	"strings"
+
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/event"
+)
+
+// OracleEmitterABI is the subset of the OracleEmitter contract ABI this
+// binding needs: the NewOracleRequest event.
+const OracleEmitterABI = `[{"anonymous":false,"inputs":[{"indexed":true,"internalType":"bytes32","name":"requestId","type":"bytes32"},{"indexed":true,"internalType":"bytes32","name":"poolId","type":"bytes32"},{"indexed":true,"internalType":"address","name":"requester","type":"address"},{"indexed":false,"internalType":"uint256","name":"timestamp","type":"uint256"}],"name":"NewOracleRequest","type":"event"}]`
+
+// OracleEmitterNewOracleRequest is the decoded form of a NewOracleRequest
+// log. Raw.Removed is set when the log is reported as removed by a chain
+// reorg.
+type OracleEmitterNewOracleRequest struct {
+	RequestId [32]byte
+	PoolId    [32]byte
+	Requester common.Address
+	Timestamp *big.Int
+	Raw       types.Log
+}
+
+// OracleEmitterFilterer watches and decodes NewOracleRequest events from a
+// deployed OracleEmitter contract.
+type OracleEmitterFilterer struct {
+	contract *bind.BoundContract
+}
+
+// NewOracleEmitterFilterer binds to address using filterer (typically an
+// *ethclient.Client) as the log source.
+func NewOracleEmitterFilterer(address common.Address, filterer bind.ContractFilterer) (*OracleEmitterFilterer, error) {
+	parsed, err := abi.JSON(strings.NewReader(OracleEmitterABI))
+	if err != nil {
+		return nil, err
+	}
+	contract := bind.NewBoundContract(address, parsed, nil, nil, filterer)
+	return &OracleEmitterFilterer{contract: contract}, nil
+}
+
+// WatchNewOracleRequest subscribes to NewOracleRequest logs, optionally
+// filtered by the indexed requestId/poolId/requester fields, and decodes
+// each log (including removed-log reorg notifications) onto sink.
+func (f *OracleEmitterFilterer) WatchNewOracleRequest(opts *bind.WatchOpts, sink chan<- *OracleEmitterNewOracleRequest, requestId [][32]byte, poolId [][32]byte, requester []common.Address) (event.Subscription, error) {
+	var requestIdRule []interface{}
+	for _, r := range requestId {
+		requestIdRule = append(requestIdRule, r)
+	}
+	var poolIdRule []interface{}
+	for _, p := range poolId {
+		poolIdRule = append(poolIdRule, p)
+	}
+	var requesterRule []interface{}
+	for _, r := range requester {
+		requesterRule = append(requesterRule, r)
+	}
+
+	logs, sub, err := f.contract.WatchLogs(opts, "NewOracleRequest", requestIdRule, poolIdRule, requesterRule)
+	if err != nil {
+		return nil, err
+	}
+
+	return event.NewSubscription(func(quit <-chan struct{}) error {
+		defer close(sink)
+		for {
+			select {
+			case log, ok := <-logs:
+				if !ok {
+					return nil
+				}
+				ev, err := f.unpackNewOracleRequest(log)
+				if err != nil {
+					return err
+				}
+				select {
+				case sink <- ev:
+				case err := <-sub.Err():
+					return err
+				case <-quit:
+					return nil
+				}
+			case err := <-sub.Err():
+				return err
+			case <-quit:
+				return nil
+			}
+		}
+	}), nil
+}
+
+// ParseNewOracleRequest decodes a single NewOracleRequest log, e.g. when
+// backfilling from FilterLogs rather than a live subscription.
+func (f *OracleEmitterFilterer) ParseNewOracleRequest(log types.Log) (*OracleEmitterNewOracleRequest, error) {
+	return f.unpackNewOracleRequest(log)
+}
+
+func (f *OracleEmitterFilterer) unpackNewOracleRequest(log types.Log) (*OracleEmitterNewOracleRequest, error) {
+	ev := new(OracleEmitterNewOracleRequest)
+	if err := f.contract.UnpackLog(ev, "NewOracleRequest", log); err != nil {
+		return nil, err
+	}
+	ev.Raw = log
+	return ev, nil
+}
@@ -0,0 +1,332 @@
+// Package attestationpool batches per-round price attestations into a
+// Merkle tree so a single BLS-aggregated signature over the root can stand
+// in for every individual attestation on-chain, with per-leaf proofs
+// available for disputes. Modeled on the mempool/block-pool split used by
+// Filecoin's Dione consensus client.
+package attestationpool
+
+import (
+	"encoding/json"
+	"fmt"
+	"math/big"
+	"os"
+	"path/filepath"
+	"sort"
+	"sync"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/crypto"
+)
+
+// PriceAttestation is a single operator's signed observation of a pool's
+// price for a given round.
+type PriceAttestation struct {
+	PoolID     string
+	Price      *big.Int
+	SourceHash [32]byte
+	Timestamp  int64
+	Operator   common.Address
+	Round      uint64
+	// BeaconEntrySignature is the drand signature for Round that drove
+	// this attestation's source selection. It is part of the leaf
+	// preimage so the Merkle root (and whatever gets BLS-signed over it)
+	// commits to which beacon round the reporting operator claims to have
+	// used, letting a verifier reject an attestation that names a round
+	// but was actually built from a different (favorable) one.
+	BeaconEntrySignature []byte
+	// Signature is the operator's signature over the attestation, carried
+	// alongside it (but not itself leaf-hashed) so that consensus
+	// validation can embed it in SlashingEvidence without the slashing
+	// handler having to re-fetch it from the original attestation.
+	Signature []byte
+}
+
+// LeafKey identifies an attestation slot for deduplication and proof
+// lookup: one attestation per operator per pool per round.
+type LeafKey struct {
+	PoolID   string
+	Operator common.Address
+	Round    uint64
+}
+
+// Batch is the result of closing a round: the Merkle root to be
+// BLS-signed, the sorted leaves that went into it, and a proof for each
+// leaf.
+type Batch struct {
+	Round  uint64
+	Root   []byte
+	Leaves [][]byte
+	Proofs map[LeafKey][][]byte
+}
+
+// AttestationPool accepts individual price attestations, deduplicates
+// them, and batches each round into a Merkle tree on close.
+type AttestationPool struct {
+	mu      sync.Mutex
+	pending map[uint64]map[LeafKey]PriceAttestation
+	closed  map[uint64]*Batch
+}
+
+// NewAttestationPool constructs an empty pool.
+func NewAttestationPool() *AttestationPool {
+	return &AttestationPool{
+		pending: make(map[uint64]map[LeafKey]PriceAttestation),
+		closed:  make(map[uint64]*Batch),
+	}
+}
+
+// Submit adds an attestation to the pool, overwriting any earlier
+// attestation from the same operator for the same pool and round.
+func (p *AttestationPool) Submit(att PriceAttestation) error {
+	if att.PoolID == "" {
+		return fmt.Errorf("attestation pool_id cannot be empty")
+	}
+	if att.Price == nil || att.Price.Sign() <= 0 {
+		return fmt.Errorf("attestation price must be positive")
+	}
+
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	k := LeafKey{PoolID: att.PoolID, Operator: att.Operator, Round: att.Round}
+	round, ok := p.pending[att.Round]
+	if !ok {
+		round = make(map[LeafKey]PriceAttestation)
+		p.pending[att.Round] = round
+	}
+	round[k] = att
+	return nil
+}
+
+// AttestationsForRound returns a snapshot of every attestation currently
+// pending for poolID in round, for consensus validation to aggregate
+// before the round is closed into a batch.
+func (p *AttestationPool) AttestationsForRound(poolID string, round uint64) []PriceAttestation {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	var atts []PriceAttestation
+	for k, att := range p.pending[round] {
+		if k.PoolID == poolID {
+			atts = append(atts, att)
+		}
+	}
+	return atts
+}
+
+// CloseRound builds the Merkle tree for every attestation submitted to
+// round, stores it, and returns the resulting Batch. Closing a round with
+// no attestations is an error, as there is nothing to sign.
+func (p *AttestationPool) CloseRound(round uint64) (*Batch, error) {
+	p.mu.Lock()
+	pending, ok := p.pending[round]
+	if !ok || len(pending) == 0 {
+		p.mu.Unlock()
+		return nil, fmt.Errorf("no attestations pending for round %d", round)
+	}
+	delete(p.pending, round)
+	p.mu.Unlock()
+
+	keys := make([]LeafKey, 0, len(pending))
+	for k := range pending {
+		keys = append(keys, k)
+	}
+
+	leafBytes := make(map[LeafKey][]byte, len(keys))
+	for _, k := range keys {
+		leafBytes[k] = leafHash(pending[k])
+	}
+
+	sort.Slice(keys, func(i, j int) bool {
+		return string(leafBytes[keys[i]]) < string(leafBytes[keys[j]])
+	})
+
+	leaves := make([][]byte, len(keys))
+	for i, k := range keys {
+		leaves[i] = leafBytes[k]
+	}
+
+	root, layers := buildMerkleTree(leaves)
+
+	proofs := make(map[LeafKey][][]byte, len(keys))
+	for i, k := range keys {
+		proofs[k] = proofForIndex(layers, i)
+	}
+
+	batch := &Batch{
+		Round:  round,
+		Root:   root,
+		Leaves: leaves,
+		Proofs: proofs,
+	}
+
+	p.mu.Lock()
+	p.closed[round] = batch
+	p.mu.Unlock()
+
+	return batch, nil
+}
+
+// GetProof returns the Merkle proof for the given pool/operator's most
+// recently closed attestation, so dispute tooling can verify a single
+// price against a previously signed root without needing every leaf.
+func (p *AttestationPool) GetProof(poolID string, operator common.Address) ([][]byte, error) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	var best *Batch
+	var bestKey LeafKey
+	for round, batch := range p.closed {
+		k := LeafKey{PoolID: poolID, Operator: operator, Round: round}
+		if proof, ok := batch.Proofs[k]; ok {
+			if best == nil || round > best.Round {
+				best = batch
+				bestKey = k
+				_ = proof
+			}
+		}
+	}
+	if best == nil {
+		return nil, fmt.Errorf("no closed attestation for pool %s operator %s", poolID, operator.Hex())
+	}
+	return best.Proofs[bestKey], nil
+}
+
+// PruneAcceptedBatches discards closed batches at or below round, once the
+// chain has confirmed they were accepted and they're no longer needed for
+// disputes.
+func (p *AttestationPool) PruneAcceptedBatches(round uint64) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	for r := range p.closed {
+		if r <= round {
+			delete(p.closed, r)
+		}
+	}
+}
+
+// SaveToDisk persists every still-pending (not yet closed) attestation to
+// path as JSON, so a restart doesn't lose a round's in-progress
+// attestations. It is not meant to durably store closed batches; those
+// are only needed in memory until PruneAcceptedBatches clears them.
+func (p *AttestationPool) SaveToDisk(path string) error {
+	p.mu.Lock()
+	pending := make([]PriceAttestation, 0)
+	for _, round := range p.pending {
+		for _, att := range round {
+			pending = append(pending, att)
+		}
+	}
+	p.mu.Unlock()
+
+	data, err := json.Marshal(pending)
+	if err != nil {
+		return fmt.Errorf("encoding pending attestations: %w", err)
+	}
+
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		return fmt.Errorf("creating attestation db directory: %w", err)
+	}
+	if err := os.WriteFile(path, data, 0o644); err != nil {
+		return fmt.Errorf("writing attestation db %s: %w", path, err)
+	}
+	return nil
+}
+
+// LoadFromDisk restores attestations previously written by SaveToDisk,
+// re-submitting each one through Submit. A missing file is not an error:
+// it just means there was nothing pending across the restart.
+func (p *AttestationPool) LoadFromDisk(path string) error {
+	data, err := os.ReadFile(path)
+	if os.IsNotExist(err) {
+		return nil
+	}
+	if err != nil {
+		return fmt.Errorf("reading attestation db %s: %w", path, err)
+	}
+
+	var pending []PriceAttestation
+	if err := json.Unmarshal(data, &pending); err != nil {
+		return fmt.Errorf("decoding attestation db %s: %w", path, err)
+	}
+
+	for _, att := range pending {
+		if err := p.Submit(att); err != nil {
+			return fmt.Errorf("restoring attestation for pool %s operator %s: %w", att.PoolID, att.Operator.Hex(), err)
+		}
+	}
+	return nil
+}
+
+// leafHash computes keccak256(abi.encodePacked(poolId, price, sourceHash,
+// operator, timestamp, round, beaconEntrySignature)), matching the packing
+// an on-chain verifier doing abi.encodePacked(bytes(poolId), price,
+// sourceHash, operator, timestamp, round, beaconEntrySignature) would
+// produce: poolId (a dynamic type) is concatenated as its raw bytes with
+// no length prefix or hashing, price/timestamp/round (uint256) are packed
+// as their full 32-byte big-endian representation, sourceHash (bytes32)
+// is used as-is, operator (address) packs to its 20 raw bytes, and
+// beaconEntrySignature (bytes) is appended raw. Binding round and
+// beaconEntrySignature into the leaf means the signed Merkle root commits
+// to which drand round drove this attestation's source selection, so a
+// verifier re-deriving DeriveSubset(round, ...) can confirm the operator
+// didn't claim one round while actually sampling sources selected by
+// another.
+func leafHash(att PriceAttestation) []byte {
+	var packed []byte
+	packed = append(packed, []byte(att.PoolID)...)
+	packed = append(packed, common.LeftPadBytes(att.Price.Bytes(), 32)...)
+	packed = append(packed, att.SourceHash[:]...)
+	packed = append(packed, att.Operator.Bytes()...)
+	packed = append(packed, common.LeftPadBytes(big.NewInt(att.Timestamp).Bytes(), 32)...)
+	packed = append(packed, common.LeftPadBytes(new(big.Int).SetUint64(att.Round).Bytes(), 32)...)
+	packed = append(packed, att.BeaconEntrySignature...)
+	return crypto.Keccak256(packed)
+}
+
+// buildMerkleTree builds a standard pairwise Merkle tree over sorted
+// leaves, duplicating the final node at each level when the count is odd.
+// It returns the root and every layer (leaves first, root last) so proofs
+// can be derived for any leaf index.
+func buildMerkleTree(leaves [][]byte) ([]byte, [][][]byte) {
+	if len(leaves) == 0 {
+		return nil, nil
+	}
+
+	layers := [][][]byte{leaves}
+	current := leaves
+	for len(current) > 1 {
+		next := make([][]byte, 0, (len(current)+1)/2)
+		for i := 0; i < len(current); i += 2 {
+			if i+1 == len(current) {
+				next = append(next, crypto.Keccak256(append(append([]byte{}, current[i]...), current[i]...)))
+			} else {
+				next = append(next, crypto.Keccak256(append(append([]byte{}, current[i]...), current[i+1]...)))
+			}
+		}
+		layers = append(layers, next)
+		current = next
+	}
+	return current[0], layers
+}
+
+// proofForIndex walks up the layers from leaf index, collecting the
+// sibling hash at each level.
+func proofForIndex(layers [][][]byte, index int) [][]byte {
+	var proof [][]byte
+	for _, layer := range layers[:len(layers)-1] {
+		var siblingIdx int
+		if index%2 == 0 {
+			siblingIdx = index + 1
+		} else {
+			siblingIdx = index - 1
+		}
+		if siblingIdx < len(layer) {
+			proof = append(proof, layer[siblingIdx])
+		} else {
+			proof = append(proof, layer[index])
+		}
+		index /= 2
+	}
+	return proof
+}
@@ -0,0 +1,326 @@
+// Package beacon provides access to the drand randomness beacon so that
+// Oracle task processing can derive unbiasable, unpredictable-in-advance
+// selections (e.g. which exchange sources to sample, which windows to
+// audit) from a value no single operator controls.
+package beacon
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/binary"
+	"fmt"
+	"sort"
+	"sync"
+	"time"
+)
+
+// BeaconEntry is a single round of drand output.
+type BeaconEntry struct {
+	Round             uint64
+	Randomness        []byte
+	Signature         []byte
+	PreviousSignature []byte
+	Timestamp         time.Time
+}
+
+// BeaconNetwork describes a drand chain the beacon can source rounds from,
+// along with the round at which it became active. Chains are occasionally
+// rotated (e.g. a League-of-Entropy re-share); keeping the prior chain's
+// Start boundary lets VerifyEntry walk backwards through history instead of
+// losing it when a new chain comes online.
+type BeaconNetwork struct {
+	Name        string
+	ChainHash   string
+	GenesisTime time.Time
+	Period      time.Duration
+	Start       uint64
+}
+
+// BeaconNetworks is the ordered (oldest first) history of drand chains this
+// beacon has consumed.
+var BeaconNetworks = []BeaconNetwork{
+	{
+		Name:        "league-of-entropy-mainnet",
+		ChainHash:   "8990e7a9aaed2ffed73dbd7092123d6f289930540d7651336225dc172e51b2a9",
+		GenesisTime: time.Unix(1595431050, 0),
+		Period:      30 * time.Second,
+		Start:       0,
+	},
+}
+
+// networkForRound returns the chain that was active for the given round,
+// walking backwards through BeaconNetworks.
+func networkForRound(round uint64) (BeaconNetwork, error) {
+	for i := len(BeaconNetworks) - 1; i >= 0; i-- {
+		if round >= BeaconNetworks[i].Start {
+			return BeaconNetworks[i], nil
+		}
+	}
+	return BeaconNetwork{}, fmt.Errorf("no beacon network covers round %d", round)
+}
+
+// RoundAt returns the drand round whose window contains t, using the
+// network active at that time.
+func RoundAt(t time.Time) uint64 {
+	net := BeaconNetworks[len(BeaconNetworks)-1]
+	if t.Before(net.GenesisTime) {
+		return net.Start
+	}
+	elapsed := t.Sub(net.GenesisTime)
+	return net.Start + uint64(elapsed/net.Period)
+}
+
+// BeaconAPI is the interface OraclePerformer depends on. It is satisfied by
+// DrandBeacon in production and can be satisfied by a fixed-entry fake in
+// tests and conformance vectors.
+type BeaconAPI interface {
+	// Entry returns the beacon entry for round, blocking until it is
+	// available or ctx is done.
+	Entry(ctx context.Context, round uint64) (BeaconEntry, error)
+	// VerifyEntry checks that cur is a structurally valid continuation of
+	// prev (cur.Round immediately follows prev.Round and chains its
+	// PreviousSignature to prev.Signature). It does NOT perform the BLS
+	// pairing check against the chain's group public key, so it cannot by
+	// itself detect a forged entry with fabricated Randomness/Signature
+	// bytes - see the TODO on DrandBeacon.VerifyEntry.
+	VerifyEntry(prev, cur BeaconEntry) error
+	// LatestBeaconRound returns the newest round this beacon has observed.
+	LatestBeaconRound() uint64
+}
+
+// HTTPClient is the subset of a drand HTTP/gossip client that DrandBeacon
+// needs. It is an interface so the real gossip-relay client can be swapped
+// for an HTTP-polling fallback without changing DrandBeacon.
+type HTTPClient interface {
+	// Get fetches a single round (round == 0 means "latest").
+	Get(ctx context.Context, round uint64) (BeaconEntry, error)
+	// Watch streams newly produced rounds until ctx is cancelled.
+	Watch(ctx context.Context) (<-chan BeaconEntry, error)
+}
+
+// DrandBeacon is a BeaconAPI backed by a drand HTTP/gossip client, with an
+// in-memory cache of recently seen rounds.
+type DrandBeacon struct {
+	client HTTPClient
+
+	mu          sync.RWMutex
+	cache       map[uint64]BeaconEntry
+	cacheOrder  []uint64
+	maxCached   int
+	latestRound uint64
+
+	subscribers []chan BeaconEntry
+	subMu       sync.Mutex
+}
+
+// NewDrandBeacon constructs a DrandBeacon that caches up to maxCached
+// recent entries.
+func NewDrandBeacon(client HTTPClient, maxCached int) *DrandBeacon {
+	if maxCached <= 0 {
+		maxCached = 256
+	}
+	return &DrandBeacon{
+		client:    client,
+		cache:     make(map[uint64]BeaconEntry),
+		maxCached: maxCached,
+	}
+}
+
+// Entry implements BeaconAPI.
+func (b *DrandBeacon) Entry(ctx context.Context, round uint64) (BeaconEntry, error) {
+	b.mu.RLock()
+	if e, ok := b.cache[round]; ok {
+		b.mu.RUnlock()
+		return e, nil
+	}
+	b.mu.RUnlock()
+
+	entry, err := b.client.Get(ctx, round)
+	if err != nil {
+		return BeaconEntry{}, fmt.Errorf("fetching drand round %d: %w", round, err)
+	}
+	b.store(entry)
+	return entry, nil
+}
+
+// VerifyEntry implements BeaconAPI, but only checks the structural chain
+// invariant (every round's PreviousSignature matches its predecessor's
+// Signature, and rounds are contiguous). It does NOT perform the BLS
+// pairing check of cur.Signature against the chain's group public key, so
+// an operator who controls cur.Signature/cur.Randomness end-to-end (e.g. a
+// compromised or malicious upstream client) can still forge a
+// structurally valid-looking entry with biased randomness and pass this
+// check, defeating the "unbiasable selection" guarantee DeriveSubset and
+// DeriveAuditWindows depend on.
+//
+// TODO: perform real BLS12-381 pairing verification of cur.Signature
+// against the active BeaconNetwork's group public key (not currently
+// tracked on BeaconNetwork) using a pairing library such as
+// github.com/drand/kyber, before trusting cur.Randomness for selection.
+func (b *DrandBeacon) VerifyEntry(prev, cur BeaconEntry) error {
+	if cur.Round != prev.Round+1 {
+		return fmt.Errorf("round %d does not follow round %d", cur.Round, prev.Round)
+	}
+	if len(cur.PreviousSignature) == 0 || string(cur.PreviousSignature) != string(prev.Signature) {
+		return fmt.Errorf("round %d previous signature does not match round %d signature", cur.Round, prev.Round)
+	}
+	return nil
+}
+
+// LatestBeaconRound implements BeaconAPI.
+func (b *DrandBeacon) LatestBeaconRound() uint64 {
+	b.mu.RLock()
+	defer b.mu.RUnlock()
+	return b.latestRound
+}
+
+// Start begins watching the underlying client for newly produced rounds,
+// caching them and fanning them out to NewEntries subscribers. It blocks
+// until ctx is cancelled.
+func (b *DrandBeacon) Start(ctx context.Context) error {
+	entries, err := b.client.Watch(ctx)
+	if err != nil {
+		return fmt.Errorf("watching drand chain: %w", err)
+	}
+	for {
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case e, ok := <-entries:
+			if !ok {
+				return nil
+			}
+			b.store(e)
+			b.publish(e)
+		}
+	}
+}
+
+// NewEntries returns a channel of beacon entries as they arrive, for
+// consumers that want to drive new attestation rounds off beacon arrivals
+// rather than a timer.
+func (b *DrandBeacon) NewEntries() <-chan BeaconEntry {
+	ch := make(chan BeaconEntry, 16)
+	b.subMu.Lock()
+	b.subscribers = append(b.subscribers, ch)
+	b.subMu.Unlock()
+	return ch
+}
+
+func (b *DrandBeacon) publish(e BeaconEntry) {
+	b.subMu.Lock()
+	defer b.subMu.Unlock()
+	for _, ch := range b.subscribers {
+		select {
+		case ch <- e:
+		default:
+			// Slow subscriber; drop rather than block the watch loop.
+		}
+	}
+}
+
+func (b *DrandBeacon) store(e BeaconEntry) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	if _, exists := b.cache[e.Round]; !exists {
+		b.cacheOrder = append(b.cacheOrder, e.Round)
+		if len(b.cacheOrder) > b.maxCached {
+			oldest := b.cacheOrder[0]
+			b.cacheOrder = b.cacheOrder[1:]
+			delete(b.cache, oldest)
+		}
+	}
+	b.cache[e.Round] = e
+	if e.Round > b.latestRound {
+		b.latestRound = e.Round
+	}
+}
+
+// DeriveSubset deterministically selects n items from candidates using the
+// entry's randomness as a seed, so that every performer re-deriving the
+// subset from the same (entry, candidates, n) arrives at the same answer,
+// but an operator cannot predict it before the round is produced.
+func DeriveSubset(entry BeaconEntry, candidates []string, n int) []string {
+	if n <= 0 || len(candidates) == 0 {
+		return nil
+	}
+	if n > len(candidates) {
+		n = len(candidates)
+	}
+
+	sorted := append([]string(nil), candidates...)
+	sort.Strings(sorted)
+
+	keyed := make([]struct {
+		key  [32]byte
+		item string
+	}, len(sorted))
+	for i, c := range sorted {
+		keyed[i].item = c
+		keyed[i].key = roundKey(entry, c)
+	}
+	sort.Slice(keyed, func(i, j int) bool {
+		return string(keyed[i].key[:]) < string(keyed[j].key[:])
+	})
+
+	out := make([]string, n)
+	for i := 0; i < n; i++ {
+		out[i] = keyed[i].item
+	}
+	return out
+}
+
+// DeriveAuditWindows derives n deterministic, non-overlapping-by-index
+// offsets (in windowSize increments) into the lookback period, for
+// manipulation-challenge evidence gathering.
+func DeriveAuditWindows(entry BeaconEntry, lookback time.Duration, windowSize time.Duration, n int) []time.Duration {
+	if n <= 0 || windowSize <= 0 {
+		return nil
+	}
+	totalWindows := int(lookback / windowSize)
+	if totalWindows <= 0 {
+		return nil
+	}
+	if n > totalWindows {
+		n = totalWindows
+	}
+
+	seen := make(map[int]bool, n)
+	out := make([]time.Duration, 0, n)
+	counter := uint64(0)
+	for len(out) < n {
+		idx := int(binary.BigEndian.Uint64(roundKeyCounter(entry, counter)[:8]) % uint64(totalWindows))
+		counter++
+		if seen[idx] {
+			continue
+		}
+		seen[idx] = true
+		out = append(out, time.Duration(idx)*windowSize)
+	}
+	return out
+}
+
+func roundKey(entry BeaconEntry, item string) [32]byte {
+	h := sha256.New()
+	h.Write(entry.Randomness)
+	var roundBuf [8]byte
+	binary.BigEndian.PutUint64(roundBuf[:], entry.Round)
+	h.Write(roundBuf[:])
+	h.Write([]byte(item))
+	var out [32]byte
+	copy(out[:], h.Sum(nil))
+	return out
+}
+
+func roundKeyCounter(entry BeaconEntry, counter uint64) [32]byte {
+	h := sha256.New()
+	h.Write(entry.Randomness)
+	var buf [8]byte
+	binary.BigEndian.PutUint64(buf[:], entry.Round)
+	h.Write(buf[:])
+	binary.BigEndian.PutUint64(buf[:], counter)
+	h.Write(buf[:])
+	var out [32]byte
+	copy(out[:], h.Sum(nil))
+	return out
+}
@@ -0,0 +1,127 @@
+package beacon
+
+import (
+	"context"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"time"
+)
+
+// drandHTTPEntry mirrors the JSON shape returned by a drand HTTP relay's
+// /public/{round} and /public/latest endpoints.
+type drandHTTPEntry struct {
+	Round             uint64 `json:"round"`
+	Randomness        string `json:"randomness"`
+	Signature         string `json:"signature"`
+	PreviousSignature string `json:"previous_signature"`
+}
+
+// DrandHTTPClient implements HTTPClient by polling a drand HTTP relay. It
+// is the fallback transport when a gossip relay connection isn't
+// available; Watch works by polling at the chain's period rather than
+// receiving a push.
+type DrandHTTPClient struct {
+	BaseURL    string
+	HTTPClient *http.Client
+	Period     time.Duration
+}
+
+// NewDrandHTTPClient constructs a client against a drand HTTP relay, e.g.
+// "https://api.drand.sh/<chain-hash>".
+func NewDrandHTTPClient(baseURL string, period time.Duration) *DrandHTTPClient {
+	return &DrandHTTPClient{
+		BaseURL:    baseURL,
+		HTTPClient: &http.Client{Timeout: 10 * time.Second},
+		Period:     period,
+	}
+}
+
+// Get implements HTTPClient.
+func (c *DrandHTTPClient) Get(ctx context.Context, round uint64) (BeaconEntry, error) {
+	path := "latest"
+	if round != 0 {
+		path = fmt.Sprintf("%d", round)
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, fmt.Sprintf("%s/public/%s", c.BaseURL, path), nil)
+	if err != nil {
+		return BeaconEntry{}, err
+	}
+
+	resp, err := c.HTTPClient.Do(req)
+	if err != nil {
+		return BeaconEntry{}, fmt.Errorf("requesting drand round: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return BeaconEntry{}, fmt.Errorf("drand relay returned status %d", resp.StatusCode)
+	}
+
+	var raw drandHTTPEntry
+	if err := json.NewDecoder(resp.Body).Decode(&raw); err != nil {
+		return BeaconEntry{}, fmt.Errorf("decoding drand response: %w", err)
+	}
+	return decodeEntry(raw)
+}
+
+// Watch implements HTTPClient by polling at the chain period until a new
+// round is observed.
+func (c *DrandHTTPClient) Watch(ctx context.Context) (<-chan BeaconEntry, error) {
+	out := make(chan BeaconEntry)
+	period := c.Period
+	if period <= 0 {
+		period = 30 * time.Second
+	}
+
+	go func() {
+		defer close(out)
+		var lastRound uint64
+		ticker := time.NewTicker(period)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+				entry, err := c.Get(ctx, 0)
+				if err != nil || entry.Round <= lastRound {
+					continue
+				}
+				lastRound = entry.Round
+				select {
+				case out <- entry:
+				case <-ctx.Done():
+					return
+				}
+			}
+		}
+	}()
+
+	return out, nil
+}
+
+func decodeEntry(raw drandHTTPEntry) (BeaconEntry, error) {
+	randomness, err := hex.DecodeString(raw.Randomness)
+	if err != nil {
+		return BeaconEntry{}, fmt.Errorf("decoding randomness: %w", err)
+	}
+	signature, err := hex.DecodeString(raw.Signature)
+	if err != nil {
+		return BeaconEntry{}, fmt.Errorf("decoding signature: %w", err)
+	}
+	var prevSig []byte
+	if raw.PreviousSignature != "" {
+		prevSig, err = hex.DecodeString(raw.PreviousSignature)
+		if err != nil {
+			return BeaconEntry{}, fmt.Errorf("decoding previous signature: %w", err)
+		}
+	}
+	return BeaconEntry{
+		Round:             raw.Round,
+		Randomness:        randomness,
+		Signature:         signature,
+		PreviousSignature: prevSig,
+	}, nil
+}
@@ -0,0 +1,54 @@
+// Package config loads the Oracle performer's reloadable configuration:
+// the settings an operator can change with a SIGHUP rather than a full
+// restart.
+package config
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+)
+
+// Config holds the subset of performer settings that can be hot-reloaded.
+type Config struct {
+	// PriceSourceEndpoints maps an exchange source name (as used in
+	// allPriceSources) to the URL the performer should query for it.
+	PriceSourceEndpoints map[string]string `json:"price_source_endpoints"`
+	// BLSKeyPath is the filesystem path to the operator's BLS key used
+	// to sign attestations and batch roots.
+	BLSKeyPath string `json:"bls_key_path"`
+	// SlashingThresholdBps is the deviation (in basis points) from
+	// consensus above which an attestation is classified as
+	// manipulation rather than a mere outlier.
+	SlashingThresholdBps int `json:"slashing_threshold_bps"`
+	// EnabledTaskTypes restricts which task types this operator will
+	// accept; an empty slice means all task types are enabled.
+	EnabledTaskTypes []string `json:"enabled_task_types"`
+}
+
+// Load reads and parses a Config from path.
+func Load(path string) (*Config, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("reading config %s: %w", path, err)
+	}
+	var cfg Config
+	if err := json.Unmarshal(data, &cfg); err != nil {
+		return nil, fmt.Errorf("parsing config %s: %w", path, err)
+	}
+	return &cfg, nil
+}
+
+// TaskTypeEnabled reports whether taskType is permitted under cfg. A nil
+// Config or an empty EnabledTaskTypes permits every task type.
+func (c *Config) TaskTypeEnabled(taskType string) bool {
+	if c == nil || len(c.EnabledTaskTypes) == 0 {
+		return true
+	}
+	for _, t := range c.EnabledTaskTypes {
+		if t == taskType {
+			return true
+		}
+	}
+	return false
+}
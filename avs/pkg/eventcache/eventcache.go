@@ -0,0 +1,93 @@
+// Package eventcache caches on-chain OracleRequest events by request ID so
+// OraclePerformer can cross-reference a task against the request that
+// triggered it, and can evict + cancel in-flight work when a reorg
+// removes the log that created it.
+package eventcache
+
+import (
+	"context"
+	"fmt"
+	"sync"
+
+	"github.com/VictoriaMetrics/fastcache"
+	"github.com/fxamacker/cbor/v2"
+
+	"github.com/Najnomics/AVS-Oracle-Hook/avs/contracts/oracleemitter"
+)
+
+// Cache stores CBOR-encoded OracleRequest events in a fastcache, keyed by
+// request ID, and tracks cancel funcs for tasks currently in flight for a
+// request so a reorg can abort them.
+type Cache struct {
+	store *fastcache.Cache
+
+	mu       sync.Mutex
+	inFlight map[[32]byte]context.CancelFunc
+}
+
+// NewCache constructs a Cache backed by a fastcache of maxBytes capacity.
+func NewCache(maxBytes int) *Cache {
+	return &Cache{
+		store:    fastcache.New(maxBytes),
+		inFlight: make(map[[32]byte]context.CancelFunc),
+	}
+}
+
+// Put stores or overwrites the cached event for its request ID.
+func (c *Cache) Put(ev *oracleemitter.OracleEmitterNewOracleRequest) error {
+	data, err := cbor.Marshal(ev)
+	if err != nil {
+		return fmt.Errorf("encoding oracle request event: %w", err)
+	}
+	c.store.Set(ev.RequestId[:], data)
+	return nil
+}
+
+// Get returns the cached event for requestId, if present.
+func (c *Cache) Get(requestId [32]byte) (*oracleemitter.OracleEmitterNewOracleRequest, bool, error) {
+	data, ok := c.store.HasGet(nil, requestId[:])
+	if !ok {
+		return nil, false, nil
+	}
+	var ev oracleemitter.OracleEmitterNewOracleRequest
+	if err := cbor.Unmarshal(data, &ev); err != nil {
+		return nil, false, fmt.Errorf("decoding cached oracle request event: %w", err)
+	}
+	return &ev, true, nil
+}
+
+// Evict removes the cached event for requestId, e.g. because the log that
+// created it was removed by a reorg.
+func (c *Cache) Evict(requestId [32]byte) {
+	c.store.Del(requestId[:])
+}
+
+// TrackInFlight records cancel as the way to abort the task currently
+// running for requestId.
+func (c *Cache) TrackInFlight(requestId [32]byte, cancel context.CancelFunc) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.inFlight[requestId] = cancel
+}
+
+// UntrackInFlight stops tracking requestId, e.g. once its task completes.
+func (c *Cache) UntrackInFlight(requestId [32]byte) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	delete(c.inFlight, requestId)
+}
+
+// CancelInFlight cancels and stops tracking the task running for
+// requestId, if any. It is a no-op if no task is in flight for that
+// request, which is the common case when a removed log never had a task
+// derived from it yet.
+func (c *Cache) CancelInFlight(requestId [32]byte) {
+	c.mu.Lock()
+	cancel, ok := c.inFlight[requestId]
+	delete(c.inFlight, requestId)
+	c.mu.Unlock()
+
+	if ok {
+		cancel()
+	}
+}
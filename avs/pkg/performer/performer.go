@@ -0,0 +1,839 @@
+// Package performer implements the Hourglass Performer interface for
+// Oracle tasks. It is factored out of cmd/main.go so that both the
+// production binary and the conformance-vector runner can construct an
+// OraclePerformer and drive it against the same task-handling logic.
+package performer
+
+import (
+	"bytes"
+	"context"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"math/big"
+	"sort"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	performerV1 "github.com/Layr-Labs/protocol-apis/gen/protos/eigenlayer/hourglass/v1/performer"
+	"go.uber.org/zap"
+
+	"github.com/ethereum/go-ethereum/common"
+
+	"github.com/Najnomics/AVS-Oracle-Hook/avs/contracts/oracleemitter"
+	"github.com/Najnomics/AVS-Oracle-Hook/avs/pkg/attestationpool"
+	"github.com/Najnomics/AVS-Oracle-Hook/avs/pkg/beacon"
+	"github.com/Najnomics/AVS-Oracle-Hook/avs/pkg/config"
+	"github.com/Najnomics/AVS-Oracle-Hook/avs/pkg/eventcache"
+)
+
+// sourcesPerAttestation is the number of exchange sources sampled for each
+// price attestation, drawn from allPriceSources via the drand beacon.
+const sourcesPerAttestation = 3
+
+// auditWindowsPerChallenge is the number of historical windows sampled for
+// manipulation-challenge evidence, drawn via the drand beacon.
+const auditWindowsPerChallenge = 3
+
+// manipulationLookback and manipulationWindowSize bound the audit-window
+// derivation for manipulation challenges.
+const (
+	manipulationLookback   = 1 * time.Hour
+	manipulationWindowSize = 1 * time.Minute
+)
+
+// allPriceSources is the full universe of exchange price sources a
+// performer can sample from; handlePriceAttestation draws a
+// beacon-derived subset of these per round.
+var allPriceSources = []string{"binance", "coinbase", "kraken", "okx", "bybit", "bitstamp"}
+
+// TaskType represents the different types of Oracle tasks
+type TaskType string
+
+const (
+	TaskTypePriceAttestation      TaskType = "price_attestation"
+	TaskTypeConsensusValidation   TaskType = "consensus_validation"
+	TaskTypeManipulationChallenge TaskType = "manipulation_challenge"
+	TaskTypeOperatorSlashing      TaskType = "operator_slashing"
+	TaskTypeBatchCommit           TaskType = "batch_commit"
+)
+
+// TaskPayload represents the structure of task payload data
+type TaskPayload struct {
+	Type       TaskType               `json:"type"`
+	Parameters map[string]interface{} `json:"parameters"`
+}
+
+// parseTaskPayload extracts and parses the task payload from TaskRequest
+func parseTaskPayload(t *performerV1.TaskRequest) (*TaskPayload, error) {
+	var payload TaskPayload
+	if err := json.Unmarshal(t.Payload, &payload); err != nil {
+		return nil, fmt.Errorf("failed to parse task payload: %w", err)
+	}
+	return &payload, nil
+}
+
+// OraclePerformer implements the Hourglass Performer interface for Oracle tasks.
+// This offchain binary is run by Operators running the Hourglass Executor. It contains
+// the business logic of the Oracle AVS and performs work based on tasks sent to it.
+//
+// The Hourglass Aggregator ingests tasks from the TaskMailbox and distributes work
+// to Executors configured to run the Oracle Performer. Performers execute the work and
+// return the result to the Executor where the result is signed and returned to the
+// Aggregator to place in the outbox once the signing threshold is met.
+type OraclePerformer struct {
+	logger *zap.Logger
+	beacon beacon.BeaconAPI
+	pool   *attestationpool.AttestationPool
+	events *eventcache.Cache
+
+	cfg   atomic.Value // *config.Config
+	stake atomic.Value // StakeProvider
+
+	inFlight sync.WaitGroup
+}
+
+// StakeProvider resolves an operator's delegated stake, used to weight
+// consensus validation. The production implementation reads it from
+// EigenLayer's DelegationManager (see avs/contracts/delegationmanager);
+// an operator with no configured StakeProvider falls back to equal
+// weighting, which is what the conformance harness and early-stage
+// deployments without a wired DelegationManager client get.
+type StakeProvider interface {
+	OperatorStake(ctx context.Context, operator common.Address) (*big.Int, error)
+}
+
+// equalStake is the StakeProvider used when none has been configured: it
+// weights every operator identically, degrading consensus validation to a
+// plain (unweighted) median rather than failing outright.
+type equalStake struct{}
+
+func (equalStake) OperatorStake(context.Context, common.Address) (*big.Int, error) {
+	return big.NewInt(1), nil
+}
+
+func NewOraclePerformer(logger *zap.Logger, beaconAPI beacon.BeaconAPI) *OraclePerformer {
+	return &OraclePerformer{
+		logger: logger,
+		beacon: beaconAPI,
+		pool:   attestationpool.NewAttestationPool(),
+		events: eventcache.NewCache(64 * 1024 * 1024),
+	}
+}
+
+// Pool exposes the attestation pool so a supervisor can persist its
+// pending state across restarts.
+func (op *OraclePerformer) Pool() *attestationpool.AttestationPool {
+	return op.pool
+}
+
+// SetStakeProvider wires a StakeProvider for consensus-validation
+// weighting, e.g. a delegationmanager.Client once an operator has
+// configured a DelegationManager address. Safe to call while tasks are
+// in flight.
+func (op *OraclePerformer) SetStakeProvider(sp StakeProvider) {
+	op.stake.Store(sp)
+}
+
+// stakeProvider returns the configured StakeProvider, or equalStake if
+// none has been set.
+func (op *OraclePerformer) stakeProvider() StakeProvider {
+	if sp, ok := op.stake.Load().(StakeProvider); ok && sp != nil {
+		return sp
+	}
+	return equalStake{}
+}
+
+// Reload atomically swaps in a newly loaded configuration, e.g. in
+// response to SIGHUP, without interrupting in-flight tasks.
+func (op *OraclePerformer) Reload(cfg *config.Config) {
+	op.cfg.Store(cfg)
+}
+
+// Config returns the most recently loaded configuration, or nil if none
+// has been loaded yet.
+func (op *OraclePerformer) Config() *config.Config {
+	cfg, _ := op.cfg.Load().(*config.Config)
+	return cfg
+}
+
+// Drain blocks until every in-flight HandleTask call has returned, or ctx
+// is done. A supervisor restart calls this before exiting the old process
+// so a long-lived slashing task can't be interrupted mid-flight and leave
+// a duplicate or conflicting on-chain challenge behind.
+func (op *OraclePerformer) Drain(ctx context.Context) error {
+	done := make(chan struct{})
+	go func() {
+		op.inFlight.Wait()
+		close(done)
+	}()
+
+	select {
+	case <-done:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+// GetOracleRequestEvent returns the cached on-chain OracleRequest event
+// that a task was derived from, so handleManipulationChallenge can
+// cross-reference the originating request when building evidence.
+func (op *OraclePerformer) GetOracleRequestEvent(requestId [32]byte) (*oracleemitter.OracleEmitterNewOracleRequest, error) {
+	ev, ok, err := op.events.Get(requestId)
+	if err != nil {
+		return nil, err
+	}
+	if !ok {
+		return nil, fmt.Errorf("no cached oracle request event for request %x", requestId)
+	}
+	return ev, nil
+}
+
+// WatchOracleRequests bridges on-chain NewOracleRequest events into
+// synthetic tasks, for "self-driving" operators who want attestations
+// triggered directly by on-chain requests rather than only by payloads
+// the Executor pushes. It caches each event by request ID and, on a
+// reorg that removes a log, evicts the cache entry and cancels any task
+// still running for it.
+func (op *OraclePerformer) WatchOracleRequests(ctx context.Context, sink <-chan *oracleemitter.OracleEmitterNewOracleRequest, handle func(context.Context, *oracleemitter.OracleEmitterNewOracleRequest)) {
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case ev, ok := <-sink:
+			if !ok {
+				return
+			}
+
+			if ev.Raw.Removed {
+				op.events.Evict(ev.RequestId)
+				op.events.CancelInFlight(ev.RequestId)
+				continue
+			}
+
+			if err := op.events.Put(ev); err != nil {
+				op.logger.Sugar().Errorw("failed to cache oracle request event", "error", err)
+				continue
+			}
+
+			taskCtx, cancel := context.WithCancel(ctx)
+			op.events.TrackInFlight(ev.RequestId, cancel)
+			go func(ev *oracleemitter.OracleEmitterNewOracleRequest) {
+				defer op.events.UntrackInFlight(ev.RequestId)
+				defer cancel()
+				handle(taskCtx, ev)
+			}(ev)
+		}
+	}
+}
+
+func (op *OraclePerformer) ValidateTask(t *performerV1.TaskRequest) error {
+	op.logger.Sugar().Infow("Validating Oracle task",
+		zap.Any("task", t),
+	)
+
+	// ------------------------------------------------------------------------
+	// Oracle Task Validation Logic
+	// ------------------------------------------------------------------------
+	// Validate that the task request data is well-formed for Oracle operations
+
+	if len(t.TaskId) == 0 {
+		return fmt.Errorf("task ID cannot be empty")
+	}
+
+	if len(t.Payload) == 0 {
+		return fmt.Errorf("task payload cannot be empty")
+	}
+
+	// Parse and validate task payload
+	payload, err := parseTaskPayload(t)
+	if err != nil {
+		return fmt.Errorf("failed to parse task payload: %w", err)
+	}
+
+	// Validate task type specific requirements
+	switch payload.Type {
+	case TaskTypePriceAttestation:
+		if err := op.validatePriceAttestationTask(payload); err != nil {
+			return fmt.Errorf("price attestation validation failed: %w", err)
+		}
+	case TaskTypeConsensusValidation:
+		if err := op.validateConsensusValidationTask(payload); err != nil {
+			return fmt.Errorf("consensus validation failed: %w", err)
+		}
+	case TaskTypeManipulationChallenge:
+		if err := op.validateManipulationChallengeTask(payload); err != nil {
+			return fmt.Errorf("manipulation challenge validation failed: %w", err)
+		}
+	case TaskTypeOperatorSlashing:
+		if err := op.validateOperatorSlashingTask(payload); err != nil {
+			return fmt.Errorf("operator slashing validation failed: %w", err)
+		}
+	case TaskTypeBatchCommit:
+		if err := op.validateBatchCommitTask(payload); err != nil {
+			return fmt.Errorf("batch commit validation failed: %w", err)
+		}
+	default:
+		return fmt.Errorf("unknown task type: %s", payload.Type)
+	}
+
+	op.logger.Sugar().Infow("Task validation successful", "taskId", string(t.TaskId))
+	return nil
+}
+
+func (op *OraclePerformer) HandleTask(t *performerV1.TaskRequest) (*performerV1.TaskResponse, error) {
+	op.inFlight.Add(1)
+	defer op.inFlight.Done()
+
+	op.logger.Sugar().Infow("Handling Oracle task",
+		zap.Any("task", t),
+	)
+
+	// ------------------------------------------------------------------------
+	// Oracle Task Processing Logic
+	// ------------------------------------------------------------------------
+	// This is where the Performer will execute Oracle-specific work
+
+	var resultBytes []byte
+	var err error
+
+	// Parse task payload to determine task type
+	payload, err := parseTaskPayload(t)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse task payload: %w", err)
+	}
+
+	if cfg := op.Config(); cfg != nil && !cfg.TaskTypeEnabled(string(payload.Type)) {
+		return nil, fmt.Errorf("task type %q is disabled by current configuration", payload.Type)
+	}
+
+	// Route to appropriate handler based on task type
+	switch payload.Type {
+	case TaskTypePriceAttestation:
+		resultBytes, err = op.handlePriceAttestation(t, payload)
+	case TaskTypeConsensusValidation:
+		resultBytes, err = op.handleConsensusValidation(t, payload)
+	case TaskTypeManipulationChallenge:
+		resultBytes, err = op.handleManipulationChallenge(t, payload)
+	case TaskTypeOperatorSlashing:
+		resultBytes, err = op.handleOperatorSlashing(t, payload)
+	case TaskTypeBatchCommit:
+		resultBytes, err = op.handleBatchCommit(t, payload)
+	default:
+		return nil, fmt.Errorf("unknown task type '%s' for task %s", payload.Type, string(t.TaskId))
+	}
+
+	if err != nil {
+		op.logger.Sugar().Errorw("Task processing failed",
+			"taskId", string(t.TaskId),
+			"error", err,
+		)
+		return nil, err
+	}
+
+	op.logger.Sugar().Infow("Task processing completed successfully",
+		"taskId", string(t.TaskId),
+		"resultSize", len(resultBytes),
+	)
+
+	return &performerV1.TaskResponse{
+		TaskId: t.TaskId,
+		Result: resultBytes,
+	}, nil
+}
+
+// priceFixedPointScale converts a float64 price into the fixed-point
+// integer representation that gets Merkle-leaf-hashed and, eventually,
+// posted on-chain.
+const priceFixedPointScale = 1e8
+
+// priceAttestationResult is the JSON-encoded result of a price attestation
+// task: an acknowledgement that the attestation was accepted into the
+// pool for the round, not the price itself. BeaconRound and BeaconEntry
+// are included so the verifier side can re-derive the same source subset
+// and confirm it against the drand signature chain, rather than trusting
+// the operator's source selection.
+type priceAttestationResult struct {
+	PoolID      string   `json:"pool_id"`
+	Operator    string   `json:"operator"`
+	Sources     []string `json:"sources"`
+	BeaconRound uint64   `json:"beacon_round"`
+	BeaconEntry string   `json:"beacon_entry"`
+	Accepted    bool     `json:"accepted"`
+}
+
+// taskTimestamp extracts the "timestamp" parameter (unix seconds) from a
+// task payload, defaulting to now if absent.
+func taskTimestamp(payload *TaskPayload) time.Time {
+	if ts, ok := payload.Parameters["timestamp"].(float64); ok {
+		return time.Unix(int64(ts), 0)
+	}
+	return time.Now()
+}
+
+// handlePriceAttestation processes price attestation tasks
+func (op *OraclePerformer) handlePriceAttestation(t *performerV1.TaskRequest, payload *TaskPayload) ([]byte, error) {
+	op.logger.Sugar().Infow("Processing price attestation task", "taskId", string(t.TaskId))
+
+	poolId, _ := payload.Parameters["pool_id"].(string)
+	price, _ := payload.Parameters["price"].(float64)
+	sourceHashHex, _ := payload.Parameters["source_hash"].(string)
+	operatorHex, _ := payload.Parameters["operator"].(string)
+	signatureHex, _ := payload.Parameters["signature"].(string)
+
+	round := beacon.RoundAt(taskTimestamp(payload))
+	entry, err := op.beacon.Entry(context.Background(), round)
+	if err != nil {
+		return nil, fmt.Errorf("fetching beacon entry for round %d: %w", round, err)
+	}
+
+	sources := beacon.DeriveSubset(entry, allPriceSources, sourcesPerAttestation)
+
+	// TODO: Fetch prices from the selected sources and calculate the
+	// weighted average price. The beacon entry bytes must be included
+	// verbatim in whatever gets BLS-signed over the batch root, so the
+	// verifier can re-derive `sources` and confirm it against the drand
+	// signature chain.
+
+	var sourceHash [32]byte
+	copy(sourceHash[:], common.FromHex(sourceHashHex))
+
+	att := attestationpool.PriceAttestation{
+		PoolID:               poolId,
+		Price:                big.NewInt(int64(price * priceFixedPointScale)),
+		SourceHash:           sourceHash,
+		Timestamp:            taskTimestamp(payload).Unix(),
+		Operator:             common.HexToAddress(operatorHex),
+		Round:                entry.Round,
+		BeaconEntrySignature: entry.Signature,
+		Signature:            common.FromHex(signatureHex),
+	}
+	if err := op.pool.Submit(att); err != nil {
+		return nil, fmt.Errorf("submitting price attestation: %w", err)
+	}
+
+	result := priceAttestationResult{
+		PoolID:      poolId,
+		Operator:    att.Operator.Hex(),
+		Sources:     sources,
+		BeaconRound: entry.Round,
+		BeaconEntry: hex.EncodeToString(entry.Signature),
+		Accepted:    true,
+	}
+
+	return json.Marshal(result)
+}
+
+// defaultOutlierK is the default multiple of the stake-weighted MAD an
+// attestation must deviate by to be classified as an outlier; twice that
+// (2k) is the manipulation threshold. Callers may override it per-task via
+// the "k" parameter.
+const defaultOutlierK = 3.0
+
+// ConsensusStatus classifies a single attestation against the
+// stake-weighted consensus price for its (poolId, round).
+type ConsensusStatus string
+
+const (
+	ConsensusStatusInConsensus  ConsensusStatus = "in_consensus"
+	ConsensusStatusOutlier      ConsensusStatus = "outlier"
+	ConsensusStatusManipulation ConsensusStatus = "manipulation"
+)
+
+// SlashingEvidence is everything handleOperatorSlashing needs to act on a
+// manipulated or outlying attestation without re-fetching the source
+// attestation or the consensus it was measured against.
+type SlashingEvidence struct {
+	Operator             string `json:"operator"`
+	ReportedPrice        string `json:"reported_price"`
+	ConsensusPrice       string `json:"consensus_price"`
+	DeviationBps         int64  `json:"deviation_bps"`
+	AttestationSignature string `json:"attestation_signature"`
+	Round                uint64 `json:"round"`
+	// Status distinguishes a merely-noted outlier from manipulation
+	// evidence worth acting on; handleOperatorSlashing only slashes the
+	// latter.
+	Status ConsensusStatus `json:"status"`
+}
+
+// ConsensusResult is the JSON-encoded result of a consensus validation
+// task: the stake-weighted median and MAD the attestations were measured
+// against, and evidence for every attestation that didn't fall within
+// consensus.
+type ConsensusResult struct {
+	PoolID       string             `json:"pool_id"`
+	Round        uint64             `json:"round"`
+	Median       string             `json:"median"`
+	MAD          string             `json:"mad"`
+	TotalStake   string             `json:"total_stake"`
+	Participants int                `json:"participants"`
+	Outliers     []SlashingEvidence `json:"outliers"`
+}
+
+// weightedEntry pairs an attestation with its resolved stake weight, for
+// stake-weighted median/MAD computation.
+type weightedEntry struct {
+	att   attestationpool.PriceAttestation
+	value *big.Int // the price, or later the deviation being ranked
+	stake *big.Int
+}
+
+// weightedMedian sorts entries by value ascending (tying on operator
+// address, lower wins) and walks cumulative stake to find the first value
+// at which at least half of total stake has been accounted for. This
+// gives every honest performer a byte-identical result for the same
+// attestation set, which the Merkle-root signing path depends on.
+func weightedMedian(entries []weightedEntry, totalStake *big.Int) *big.Int {
+	sorted := make([]weightedEntry, len(entries))
+	copy(sorted, entries)
+	sort.Slice(sorted, func(i, j int) bool {
+		if cmp := sorted[i].value.Cmp(sorted[j].value); cmp != 0 {
+			return cmp < 0
+		}
+		return bytes.Compare(sorted[i].att.Operator.Bytes(), sorted[j].att.Operator.Bytes()) < 0
+	})
+
+	cumulative := new(big.Int)
+	for _, e := range sorted {
+		cumulative.Add(cumulative, e.stake)
+		if new(big.Int).Lsh(cumulative, 1).Cmp(totalStake) >= 0 {
+			return e.value
+		}
+	}
+	return sorted[len(sorted)-1].value
+}
+
+// handleConsensusValidation computes the stake-weighted median and median
+// absolute deviation (MAD) of every price attestation pending for
+// (pool_id, round), classifies each against k*MAD / 2k*MAD thresholds,
+// and returns slashing-ready evidence for every attestation that isn't in
+// consensus.
+func (op *OraclePerformer) handleConsensusValidation(t *performerV1.TaskRequest, payload *TaskPayload) ([]byte, error) {
+	op.logger.Sugar().Infow("Processing consensus validation task", "taskId", string(t.TaskId))
+
+	poolId, _ := payload.Parameters["pool_id"].(string)
+	roundFloat, _ := payload.Parameters["round"].(float64)
+	round := uint64(roundFloat)
+
+	k := defaultOutlierK
+	if kParam, ok := payload.Parameters["k"].(float64); ok && kParam > 0 {
+		k = kParam
+	}
+
+	atts := op.pool.AttestationsForRound(poolId, round)
+	if len(atts) == 0 {
+		return nil, fmt.Errorf("no attestations pending for pool %s round %d", poolId, round)
+	}
+
+	ctx := context.Background()
+	entries := make([]weightedEntry, 0, len(atts))
+	totalStake := new(big.Int)
+	for _, att := range atts {
+		stake, err := op.stakeProvider().OperatorStake(ctx, att.Operator)
+		if err != nil {
+			op.logger.Sugar().Warnw("failed to resolve operator stake, excluding from consensus", "operator", att.Operator.Hex(), "error", err)
+			continue
+		}
+		if stake.Sign() <= 0 {
+			continue
+		}
+		entries = append(entries, weightedEntry{att: att, value: att.Price, stake: stake})
+		totalStake.Add(totalStake, stake)
+	}
+	if len(entries) == 0 {
+		return nil, fmt.Errorf("no attestations with resolvable stake for pool %s round %d", poolId, round)
+	}
+
+	median := weightedMedian(entries, totalStake)
+
+	deviations := make([]weightedEntry, len(entries))
+	for i, e := range entries {
+		deviations[i] = weightedEntry{
+			att:   e.att,
+			value: new(big.Int).Abs(new(big.Int).Sub(e.value, median)),
+			stake: e.stake,
+		}
+	}
+	mad := weightedMedian(deviations, totalStake)
+
+	madF, _ := new(big.Float).SetInt(mad).Float64()
+	outlierThreshold := k * madF
+	manipulationThreshold := 2 * k * madF
+
+	result := ConsensusResult{
+		PoolID:       poolId,
+		Round:        round,
+		Median:       median.String(),
+		MAD:          mad.String(),
+		TotalStake:   totalStake.String(),
+		Participants: len(entries),
+	}
+
+	for _, e := range entries {
+		devF, _ := new(big.Float).SetInt(new(big.Int).Abs(new(big.Int).Sub(e.value, median))).Float64()
+
+		// No "madF > 0 &&" guard here: when the honest majority agrees
+		// exactly, mad (and so both thresholds) is 0, and a lone operator
+		// reporting any different price is exactly the manipulation this
+		// task exists to catch - short-circuiting such deviations to
+		// in_consensus because the threshold also collapsed to 0 would
+		// miss it entirely.
+		status := ConsensusStatusInConsensus
+		switch {
+		case devF > manipulationThreshold:
+			status = ConsensusStatusManipulation
+		case devF > outlierThreshold:
+			status = ConsensusStatusOutlier
+		}
+		if status == ConsensusStatusInConsensus {
+			continue
+		}
+
+		var deviationBps int64
+		if medianF, _ := new(big.Float).SetInt(median).Float64(); medianF != 0 {
+			deviationBps = int64(devF / medianF * 10000)
+		}
+
+		result.Outliers = append(result.Outliers, SlashingEvidence{
+			Operator:             e.att.Operator.Hex(),
+			ReportedPrice:        e.att.Price.String(),
+			ConsensusPrice:       median.String(),
+			DeviationBps:         deviationBps,
+			AttestationSignature: hex.EncodeToString(e.att.Signature),
+			Round:                e.att.Round,
+			Status:               status,
+		})
+	}
+
+	sort.Slice(result.Outliers, func(i, j int) bool {
+		return result.Outliers[i].Operator < result.Outliers[j].Operator
+	})
+
+	return json.Marshal(result)
+}
+
+// manipulationChallengeResult is the JSON-encoded result of a manipulation
+// challenge task. Like priceAttestationResult, it includes the beacon round
+// and entry so the audit windows can be independently re-derived.
+type manipulationChallengeResult struct {
+	Operator        string          `json:"operator"`
+	BeaconRound     uint64          `json:"beacon_round"`
+	BeaconEntry     string          `json:"beacon_entry"`
+	AuditWindows    []time.Duration `json:"audit_windows_ago"`
+	OracleRequester string          `json:"oracle_requester,omitempty"`
+}
+
+// handleManipulationChallenge processes manipulation challenge tasks
+func (op *OraclePerformer) handleManipulationChallenge(t *performerV1.TaskRequest, payload *TaskPayload) ([]byte, error) {
+	op.logger.Sugar().Infow("Processing manipulation challenge task", "taskId", string(t.TaskId))
+
+	operator, _ := payload.Parameters["operator"].(string)
+
+	round := beacon.RoundAt(taskTimestamp(payload))
+	entry, err := op.beacon.Entry(context.Background(), round)
+	if err != nil {
+		return nil, fmt.Errorf("fetching beacon entry for round %d: %w", round, err)
+	}
+
+	windows := beacon.DeriveAuditWindows(entry, manipulationLookback, manipulationWindowSize, auditWindowsPerChallenge)
+
+	// TODO: Gather evidence from multiple price sources within each
+	// audit window, calculate deviation from consensus, and submit the
+	// challenge proof.
+
+	result := manipulationChallengeResult{
+		Operator:     operator,
+		BeaconRound:  entry.Round,
+		BeaconEntry:  hex.EncodeToString(entry.Signature),
+		AuditWindows: windows,
+	}
+
+	// If the challenge names the on-chain request that triggered it,
+	// cross-reference the cached event for richer evidence.
+	if requestIdHex, ok := payload.Parameters["request_id"].(string); ok && requestIdHex != "" {
+		var requestId [32]byte
+		copy(requestId[:], common.FromHex(requestIdHex))
+		if ev, err := op.GetOracleRequestEvent(requestId); err == nil {
+			result.OracleRequester = ev.Requester.Hex()
+		}
+	}
+
+	return json.Marshal(result)
+}
+
+// batchCommitResult is the JSON-encoded result of a batch commit task: the
+// Merkle root to be BLS-signed by the operator set, plus a proof for every
+// leaf so the on-chain contract (or dispute tooling) can verify an
+// individual price attestation without the chain having to store every
+// one of them.
+type batchCommitResult struct {
+	Round  uint64              `json:"round"`
+	Root   string              `json:"root"`
+	Leaves []string            `json:"leaves"`
+	Proofs map[string][]string `json:"proofs"`
+}
+
+// handleBatchCommit flushes the attestation pool for a round into a
+// Merkle-batched commitment.
+func (op *OraclePerformer) handleBatchCommit(t *performerV1.TaskRequest, payload *TaskPayload) ([]byte, error) {
+	op.logger.Sugar().Infow("Processing batch commit task", "taskId", string(t.TaskId))
+
+	roundFloat, _ := payload.Parameters["round"].(float64)
+	round := uint64(roundFloat)
+
+	batch, err := op.pool.CloseRound(round)
+	if err != nil {
+		return nil, fmt.Errorf("closing attestation round %d: %w", round, err)
+	}
+
+	leaves := make([]string, len(batch.Leaves))
+	for i, leaf := range batch.Leaves {
+		leaves[i] = hex.EncodeToString(leaf)
+	}
+
+	proofs := make(map[string][]string, len(batch.Proofs))
+	for k, proof := range batch.Proofs {
+		hexProof := make([]string, len(proof))
+		for i, sibling := range proof {
+			hexProof[i] = hex.EncodeToString(sibling)
+		}
+		proofs[fmt.Sprintf("%s:%s", k.PoolID, k.Operator.Hex())] = hexProof
+	}
+
+	result := batchCommitResult{
+		Round:  batch.Round,
+		Root:   hex.EncodeToString(batch.Root),
+		Leaves: leaves,
+		Proofs: proofs,
+	}
+
+	return json.Marshal(result)
+}
+
+// slashingResult is the JSON-encoded result of an operator slashing task.
+type slashingResult struct {
+	Evidence       SlashingEvidence `json:"evidence"`
+	SlashAmountBps int64            `json:"slash_amount_bps"`
+}
+
+// decodeSlashingEvidence extracts the "evidence" parameter - the
+// SlashingEvidence produced by handleConsensusValidation, passed through
+// verbatim by the caller - without re-fetching any source attestation
+// data.
+func decodeSlashingEvidence(payload *TaskPayload) (*SlashingEvidence, error) {
+	raw, ok := payload.Parameters["evidence"]
+	if !ok {
+		return nil, fmt.Errorf("missing evidence")
+	}
+	encoded, err := json.Marshal(raw)
+	if err != nil {
+		return nil, fmt.Errorf("re-encoding evidence: %w", err)
+	}
+	var evidence SlashingEvidence
+	if err := json.Unmarshal(encoded, &evidence); err != nil {
+		return nil, fmt.Errorf("decoding evidence: %w", err)
+	}
+	if evidence.Operator == "" {
+		return nil, fmt.Errorf("evidence missing operator")
+	}
+	if evidence.ConsensusPrice == "" || evidence.ReportedPrice == "" {
+		return nil, fmt.Errorf("evidence missing reported or consensus price")
+	}
+	return &evidence, nil
+}
+
+// handleOperatorSlashing processes operator slashing tasks. It takes its
+// SlashingEvidence directly from the task payload - produced by a prior
+// handleConsensusValidation call - rather than recomputing consensus
+// itself, so the slashing decision and the evidence it was based on can
+// never drift apart.
+func (op *OraclePerformer) handleOperatorSlashing(t *performerV1.TaskRequest, payload *TaskPayload) ([]byte, error) {
+	op.logger.Sugar().Infow("Processing operator slashing task", "taskId", string(t.TaskId))
+
+	evidence, err := decodeSlashingEvidence(payload)
+	if err != nil {
+		return nil, fmt.Errorf("invalid slashing evidence: %w", err)
+	}
+
+	if evidence.Status != ConsensusStatusManipulation {
+		return nil, fmt.Errorf("evidence for operator %s is classified %q, not manipulation; refusing to slash", evidence.Operator, evidence.Status)
+	}
+
+	// TODO: Execute the slash through EigenLayer's AllocationManager/
+	// slasher once this AVS registers a slashing strategy, and update the
+	// operator's reliability score. For now this records the decision and
+	// computed amount for the Executor/Aggregator to act on.
+
+	result := slashingResult{
+		Evidence:       *evidence,
+		SlashAmountBps: evidence.DeviationBps,
+	}
+
+	return json.Marshal(result)
+}
+
+// Oracle task validation functions
+func (op *OraclePerformer) validatePriceAttestationTask(payload *TaskPayload) error {
+	// Validate required parameters for price attestation
+	if poolId, ok := payload.Parameters["pool_id"].(string); !ok || poolId == "" {
+		return fmt.Errorf("missing or invalid pool_id")
+	}
+
+	if price, ok := payload.Parameters["price"].(float64); !ok || price <= 0 {
+		return fmt.Errorf("missing or invalid price")
+	}
+
+	if sourceHash, ok := payload.Parameters["source_hash"].(string); !ok || sourceHash == "" {
+		return fmt.Errorf("missing or invalid source_hash")
+	}
+
+	return nil
+}
+
+func (op *OraclePerformer) validateConsensusValidationTask(payload *TaskPayload) error {
+	// Validate required parameters for consensus validation
+	if poolId, ok := payload.Parameters["pool_id"].(string); !ok || poolId == "" {
+		return fmt.Errorf("missing or invalid pool_id")
+	}
+
+	if _, ok := payload.Parameters["round"].(float64); !ok {
+		return fmt.Errorf("missing or invalid round")
+	}
+
+	return nil
+}
+
+func (op *OraclePerformer) validateManipulationChallengeTask(payload *TaskPayload) error {
+	// Validate required parameters for manipulation challenge
+	if operator, ok := payload.Parameters["operator"].(string); !ok || operator == "" {
+		return fmt.Errorf("missing or invalid operator")
+	}
+
+	if evidence, ok := payload.Parameters["evidence"].(string); !ok || evidence == "" {
+		return fmt.Errorf("missing or invalid evidence")
+	}
+
+	return nil
+}
+
+func (op *OraclePerformer) validateBatchCommitTask(payload *TaskPayload) error {
+	// Validate required parameters for batch commit
+	if _, ok := payload.Parameters["round"].(float64); !ok {
+		return fmt.Errorf("missing or invalid round")
+	}
+
+	return nil
+}
+
+func (op *OraclePerformer) validateOperatorSlashingTask(payload *TaskPayload) error {
+	// Validate required parameters for operator slashing: the evidence
+	// produced by a prior consensus-validation task, taken as-is rather
+	// than re-derived from raw attestations.
+	if _, err := decodeSlashingEvidence(payload); err != nil {
+		return err
+	}
+
+	return nil
+}
@@ -0,0 +1,211 @@
+// Package supervisor manages the Oracle performer process's lifecycle
+// beyond a single run: SIGHUP triggers a configuration reload, SIGUSR2
+// triggers a zero-downtime restart that hands the listening socket to a
+// freshly exec'd process once this one has drained its in-flight tasks
+// and persisted pending attestation-pool state, and SIGTERM/SIGINT drain
+// and persist the same state before an ordinary stop.
+//
+// This matters because a crash or an ordinary restart mid-slashing-task
+// can otherwise produce duplicate or conflicting on-chain challenges.
+package supervisor
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"os"
+	"os/exec"
+	"os/signal"
+	"syscall"
+	"time"
+
+	"go.uber.org/zap"
+
+	"github.com/Najnomics/AVS-Oracle-Hook/avs/pkg/config"
+	"github.com/Najnomics/AVS-Oracle-Hook/avs/pkg/performer"
+)
+
+// ListenFDEnv is set in a restarted child's environment to signal that
+// file descriptor 3 (the first entry in os/exec's ExtraFiles) is an
+// inherited listening socket rather than something to bind fresh.
+const ListenFDEnv = "ORACLE_PERFORMER_LISTEN_FD"
+
+// AttestationDBPath is where pending attestation-pool state is persisted
+// across restarts.
+const AttestationDBPath = ".db/attestations"
+
+// DrainTimeout bounds how long a restart waits for in-flight tasks to
+// finish before giving up and exiting anyway.
+const DrainTimeout = 30 * time.Second
+
+// Supervisor installs the signal handling that drives config reloads and
+// zero-downtime restarts for a single OraclePerformer.
+type Supervisor struct {
+	logger     *zap.Logger
+	performer  *performer.OraclePerformer
+	configPath string
+	listener   net.Listener
+}
+
+// New constructs a Supervisor. listener is the TCP listener backing the
+// gRPC server; it is only used to extract a file descriptor to hand to
+// the replacement process on SIGUSR2, and may be nil if the embedding
+// server doesn't expose one (in which case restart falls back to exiting
+// without FD handoff, and the new process rebinds the port itself).
+func New(logger *zap.Logger, perf *performer.OraclePerformer, configPath string, listener net.Listener) *Supervisor {
+	return &Supervisor{
+		logger:     logger,
+		performer:  perf,
+		configPath: configPath,
+		listener:   listener,
+	}
+}
+
+// Serve restores any attestation state left by a prior restart, installs
+// signal handlers, and blocks until ctx is cancelled, reloading
+// configuration on SIGHUP, restarting on SIGUSR2, and draining/persisting
+// before exit on SIGTERM/SIGINT.
+func (s *Supervisor) Serve(ctx context.Context) {
+	if err := s.performer.Pool().LoadFromDisk(AttestationDBPath); err != nil {
+		s.logger.Sugar().Warnw("failed to restore attestation pool state", "error", err)
+	}
+
+	sig := make(chan os.Signal, 1)
+	signal.Notify(sig, syscall.SIGHUP, syscall.SIGUSR2, syscall.SIGTERM, syscall.SIGINT)
+	defer signal.Stop(sig)
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case received := <-sig:
+			switch received {
+			case syscall.SIGHUP:
+				s.reload()
+			case syscall.SIGUSR2:
+				s.restart(ctx)
+			case syscall.SIGTERM, syscall.SIGINT:
+				s.shutdown(ctx)
+			}
+		}
+	}
+}
+
+func (s *Supervisor) reload() {
+	cfg, err := config.Load(s.configPath)
+	if err != nil {
+		s.logger.Sugar().Errorw("failed to reload configuration", "path", s.configPath, "error", err)
+		return
+	}
+	s.performer.Reload(cfg)
+	s.logger.Sugar().Infow("reloaded configuration", "path", s.configPath)
+}
+
+// restart forks a replacement process carrying the inherited listener
+// FD, drains this process's in-flight tasks, persists pending
+// attestation-pool state, and exits so the replacement can take over.
+//
+// It refuses to do any of that unless it actually holds the *net.TCPListener*
+// the gRPC server is bound to: without it there is no FD to hand off, the
+// replacement process can't bind the same port, and exiting anyway would
+// just take the service down for nothing. Until the embedding server
+// exposes its listener (see the TODO in cmd/main.go), SIGUSR2 is a no-op
+// logged at error level rather than a self-inflicted outage.
+func (s *Supervisor) restart(ctx context.Context) {
+	s.logger.Info("received SIGUSR2, starting zero-downtime restart")
+
+	if s.listener == nil {
+		s.logger.Sugar().Errorw("no listener to hand off to a replacement process, refusing to restart (would take the service down without a working handoff)")
+		return
+	}
+
+	executable, err := os.Executable()
+	if err != nil {
+		s.logger.Sugar().Errorw("failed to resolve executable path, aborting restart", "error", err)
+		return
+	}
+
+	tcpListener, ok := s.listener.(*net.TCPListener)
+	if !ok {
+		s.logger.Sugar().Errorw("listener is not a *net.TCPListener, cannot extract fd, refusing to restart")
+		return
+	}
+	listenerFile, err := tcpListener.File()
+	if err != nil {
+		s.logger.Sugar().Errorw("failed to extract listener fd, refusing to restart", "error", err)
+		return
+	}
+	defer listenerFile.Close()
+
+	// Drain and persist pending attestation-pool state *before* starting
+	// the replacement: the child's Serve loads AttestationDBPath on
+	// startup, so writing it after cmd.Start() races the child's load and
+	// can hand it a stale or empty file.
+	drainCtx, cancel := context.WithTimeout(ctx, DrainTimeout)
+	defer cancel()
+	if err := s.performer.Drain(drainCtx); err != nil {
+		s.logger.Sugar().Warnw("drain timed out before all in-flight tasks completed", "error", err)
+	}
+
+	if err := s.performer.Pool().SaveToDisk(AttestationDBPath); err != nil {
+		s.logger.Sugar().Errorw("failed to persist attestation pool state, aborting restart", "error", err)
+		return
+	}
+
+	cmd := exec.Command(executable, os.Args[1:]...)
+	cmd.Stdout = os.Stdout
+	cmd.Stderr = os.Stderr
+	cmd.Env = append(os.Environ(), fmt.Sprintf("%s=1", ListenFDEnv))
+	cmd.ExtraFiles = []*os.File{listenerFile}
+
+	if err := cmd.Start(); err != nil {
+		s.logger.Sugar().Errorw("failed to start replacement process, aborting restart", "error", err)
+		return
+	}
+	s.logger.Sugar().Infow("replacement process started", "pid", cmd.Process.Pid)
+
+	s.logger.Info("drained in-flight tasks and handed off listener, exiting for replacement process")
+	os.Exit(0)
+}
+
+// shutdown drains in-flight tasks and persists pending attestation-pool
+// state before exiting on an ordinary stop (SIGTERM/SIGINT).
+//
+// restart's persistence only runs once a real listener is wired through
+// for FD handoff (see restart's doc comment), which isn't the case today
+// - so without this path, a plain process-manager stop or restart would
+// silently lose whatever attestations were pending for the round. This
+// path is independent of the listener and always runs.
+func (s *Supervisor) shutdown(ctx context.Context) {
+	s.logger.Info("received shutdown signal, draining and persisting before exit")
+
+	drainCtx, cancel := context.WithTimeout(ctx, DrainTimeout)
+	defer cancel()
+	if err := s.performer.Drain(drainCtx); err != nil {
+		s.logger.Sugar().Warnw("drain timed out before all in-flight tasks completed", "error", err)
+	}
+
+	if err := s.performer.Pool().SaveToDisk(AttestationDBPath); err != nil {
+		s.logger.Sugar().Errorw("failed to persist attestation pool state on shutdown", "error", err)
+	}
+
+	s.logger.Info("drained in-flight tasks and persisted attestation pool state, exiting")
+	os.Exit(0)
+}
+
+// InheritedListener returns the listener handed down by a parent process
+// during a SIGUSR2 restart, if this process was exec'd with one.
+func InheritedListener() (net.Listener, error) {
+	if os.Getenv(ListenFDEnv) == "" {
+		return nil, nil
+	}
+	f := os.NewFile(3, "inherited-listener")
+	if f == nil {
+		return nil, fmt.Errorf("%s set but fd 3 is not open", ListenFDEnv)
+	}
+	listener, err := net.FileListener(f)
+	if err != nil {
+		return nil, fmt.Errorf("wrapping inherited listener fd: %w", err)
+	}
+	return listener, nil
+}